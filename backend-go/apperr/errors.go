@@ -5,11 +5,40 @@ import "errors"
 // fill as we go. they do NOT mean the same thing as HTTP status codes.
 
 var (
-	ErrNotFound        = errors.New("not found")
-	ErrDuplicate       = errors.New("duplicate")
+	ErrNotFound          = errors.New("not found")
+	ErrDuplicate         = errors.New("duplicate")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrInvalidSearchType = errors.New("invalid search type")
+	ErrSelfFollow        = errors.New("cannot follow yourself")
+	ErrSelfBlock         = errors.New("cannot block yourself")
+	ErrEmptyComment      = errors.New("comment body is empty")
+	ErrNotUnlisted       = errors.New("video is not unlisted")
+	ErrSelfRoleChange    = errors.New("cannot change your own role")
+	ErrReportResolved    = errors.New("report already resolved")
+	ErrAccountDeleted    = errors.New("account is scheduled for deletion")
+
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+
+	// accounts and identities
+	ErrEmailTaken            = errors.New("email belongs to another account")
+	ErrInvalidUsername       = errors.New("username must be 3-30 letters, digits, dots or underscores, starting and ending with a letter or digit")
+	ErrReservedUsername      = errors.New("username is reserved")
+	ErrUnlinkCurrentIdentity = errors.New("cannot unlink the identity you are signed in with")
+	ErrSelfMerge             = errors.New("cannot merge an account into itself")
+	ErrMergeEmailMismatch    = errors.New("accounts do not share a verified email")
 
 	// config env errors
 	ErrDevBypassAuthNotAllowed              = errors.New("DEV_BYPASS_AUTH cannot be enabled in non-development environments")
 	ErrDevBypassAuthAuth0IDNotSet           = errors.New("DEV_AUTH0_ID is required when DEV_BYPASS_AUTH is enabled")
 	ErrInvalidSearchTrgmSimilarityThreshold = errors.New("search trigram similarity threshold must be between 0 and 1")
+	ErrCursorSigningKeyTooShort             = errors.New("CURSOR_SIGNING_KEY must be at least 32 bytes outside development")
+	ErrInvalidDataExportTTL                 = errors.New("DATA_EXPORT_TTL_HOURS must be between 1 and 168")
+	ErrInvalidPlaybackURLTTL                = errors.New("PLAYBACK_URL_TTL_MINS must be between 1 and 10080")
+	ErrInvalidRateLimitBackend              = errors.New("RATE_LIMIT_BACKEND must be memory or postgres")
+	ErrInvalidRateLimit                     = errors.New("RATE_LIMIT_*_PER_MIN must not be negative, and RATE_LIMIT_*_BURST must be at least 1 when the limit is on")
+	ErrInvalidStatsRefreshInterval          = errors.New("STATS_REFRESH_INTERVAL_MINS must be positive")
+	ErrInvalidUnifiedSearchTimeout          = errors.New("UNIFIED_SEARCH_TIMEOUT_MS must be positive")
+	ErrInvalidViewFlushInterval             = errors.New("VIEW_FLUSH_INTERVAL_SECS must be positive")
+	ErrInvalidAccountPurgeInterval          = errors.New("ACCOUNT_PURGE_INTERVAL_MINS must be positive")
 )
@@ -0,0 +1,76 @@
+// Command grant-role sets a user's role from the command line. It exists to
+// bootstrap the first admin, since role changes over the API need an admin already.
+//
+//	go run ./cmd/grant-role -user alice -role admin -reason "initial admin"
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/config"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+func main() {
+	userRef := flag.String("user", "", "username or Auth0 ID of the user")
+	role := flag.String("role", models.RoleAdmin, "role to grant: "+strings.Join(models.Roles, ", "))
+	reason := flag.String("reason", "", "optional note recorded in the audit trail")
+	flag.Parse()
+
+	if *userRef == "" {
+		log.Fatal("-user is required")
+	}
+	if !slices.Contains(models.Roles, *role) {
+		log.Fatalf("invalid role %q (want one of %s)", *role, strings.Join(models.Roles, ", "))
+	}
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	pool, err := cfg.NewDBPool(ctx)
+	if err != nil {
+		log.Fatalf("Failed to connect to database %v", err)
+	}
+	defer pool.Close()
+	store := database.NewStore(pool, cfg.Store.SearchTrgmSimilarityThreshold)
+
+	user, err := findUser(ctx, store, *userRef)
+	if err != nil {
+		log.Fatalf("Failed to find user %q: %v", *userRef, err)
+	}
+
+	var note *string
+	if *reason != "" {
+		note = reason
+	}
+	change, err := store.SetUserRole(ctx, user.ID, *role, nil, models.RoleChangeSourceCLI, note)
+	if err != nil {
+		log.Fatalf("Failed to set role: %v", err)
+	}
+	if change == nil {
+		log.Printf("%s (id %d) already has role %s", user.Username, user.ID, *role)
+		return
+	}
+	log.Printf("%s (id %d): %s -> %s", user.Username, user.ID, change.OldRole, change.NewRole)
+}
+
+// findUser looks ref up as a username first, then as an Auth0 ID.
+func findUser(ctx context.Context, store *database.Store, ref string) (*models.User, error) {
+	user, err := store.GetUserByUsername(ctx, ref)
+	if err == nil || !errors.Is(err, apperr.ErrNotFound) {
+		return user, err
+	}
+	user, err = store.GetUserByAuth0ID(ctx, ref)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return user, err
+}
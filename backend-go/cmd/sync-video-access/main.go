@@ -0,0 +1,77 @@
+// Command sync-video-access sets the ACL of every uploaded video's objects to match
+// its visibility: public-read for public videos, private for everything else.
+// Videos uploaded before per-object ACLs relied on a publicly readable bucket, so run
+// this once before removing the bucket's anonymous-read grant, then again afterwards
+// to catch videos that changed visibility in between.
+//
+//	go run ./cmd/sync-video-access
+//	go run ./cmd/sync-video-access -dry-run
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/areeeeeeeb/reLive/backend-go/config"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+)
+
+// batchSize is how many videos are loaded per query.
+const batchSize = 200
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "list the videos that would be synced without changing any ACLs")
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := config.Load()
+
+	pool, err := cfg.NewDBPool(ctx)
+	if err != nil {
+		log.Fatalf("Failed to connect to database %v", err)
+	}
+	defer pool.Close()
+	store := database.NewStore(pool, cfg.Store.SearchTrgmSimilarityThreshold)
+
+	s3Client, err := cfg.NewS3Client(ctx)
+	if err != nil {
+		log.Fatalf("Failed to connect to s3 %v", err)
+	}
+	uploadService := services.NewUploadService(s3Client, cfg.Spaces.Bucket, cfg.Spaces.CdnURL)
+	playbackService := services.NewPlaybackService(uploadService, cfg.Spaces.PlaybackURLTTL)
+
+	var synced, public, failed int
+	afterID := 0
+	for {
+		videos, err := store.ListCompletedVideosAfter(ctx, afterID, batchSize)
+		if err != nil {
+			log.Fatalf("Failed to list videos after id %d: %v", afterID, err)
+		}
+		if len(videos) == 0 {
+			break
+		}
+		for _, v := range videos {
+			afterID = v.ID
+			if *dryRun {
+				log.Printf("video %d: %s", v.ID, v.Visibility)
+			} else if err := playbackService.SyncAccess(ctx, v); err != nil {
+				// keep going; a re-run picks up whatever was missed
+				log.Printf("video %d: %v", v.ID, err)
+				failed++
+				continue
+			}
+			synced++
+			if v.Visibility == models.VideoVisibilityPublic {
+				public++
+			}
+		}
+	}
+
+	log.Printf("synced %d videos (%d public, %d private or unlisted), %d failed", synced, public, synced-public, failed)
+	if failed > 0 {
+		log.Fatal("some videos were not synced; re-run before removing the bucket's public-read grant")
+	}
+}
@@ -1,136 +1,231 @@
-package config
-
-import (
-	"os"
-	"strconv"
-	"strings"
-	"time"
-
-	"github.com/joho/godotenv"
-)
-
-type Config struct {
-	Port          string
-	Environment   string
-	DatabaseURL   string
-	Store         StoreConfig
-	DevBypassAuth bool
-	DevAuth0ID    string
-
-	Auth0  Auth0Config
-	Spaces SpacesConfig
-	Concurrency ConcurrencyConfig
-
-}
-
-type StoreConfig struct {
-	SearchTrgmSimilarityThreshold float64
-}
-
-type Auth0Config struct {
-	Domain   string
-	Audience string
-}
-
-type SpacesConfig struct {
-	Endpoint  string
-	Bucket    string
-	Region    string
-	AccessKey string
-	SecretKey string
-	CdnURL    string
-}
-
-type ConcurrencyConfig struct {
-	Concurrency       int           // POOL_CONCURRENCY — number of worker goroutines
-	QueueSize         int           // POOL_QUEUE_SIZE — job channel buffer size
-	SchedulerInterval time.Duration // SCHEDULER_INTERVAL_SECS — how often scheduler polls DB
-	StuckThreshold    time.Duration // STUCK_THRESHOLD_MINS — how long before a processing job is considered stuck
-	ResetInterval     time.Duration // RESET_INTERVAL_MINS — how often the stuck-job reset loop runs
-}
-
-func Load() *Config {
-	godotenv.Load()
-
-	return &Config{
-		Port:          getEnv("PORT", "8081"),
-		Environment:   getEnv("ENVIRONMENT", "development"),
-		DatabaseURL:   getEnv("DATABASE_URL", ""),
-		DevBypassAuth: getEnvBool("DEV_BYPASS_AUTH", false),
-		DevAuth0ID:    getEnv("DEV_AUTH0_ID", ""),
-
-		Store: StoreConfig{
-			SearchTrgmSimilarityThreshold: getEnvFloat64("SEARCH_TRGM_SIMILARITY_THRESHOLD", 0.3),
-		},
-
-		Concurrency: ConcurrencyConfig{
-			Concurrency:       getEnvInt("POOL_CONCURRENCY", 5),
-			QueueSize:         getEnvInt("POOL_QUEUE_SIZE", 50),
-			SchedulerInterval: time.Duration(getEnvInt("SCHEDULER_INTERVAL_SECS", 30)) * time.Second,
-			StuckThreshold:    time.Duration(getEnvInt("STUCK_THRESHOLD_MINS", 10)) * time.Minute,
-			ResetInterval:     time.Duration(getEnvInt("RESET_INTERVAL_MINS", 5)) * time.Minute,
-		},
-		
-		Auth0: Auth0Config{
-			Domain:   getEnv("AUTH0_DOMAIN", ""),
-			Audience: getEnv("AUTH0_AUDIENCE", ""),
-		},
-
-		Spaces: SpacesConfig{
-			Endpoint:  getEnv("DO_SPACES_ENDPOINT", ""),
-			Bucket:    getEnv("DO_SPACES_BUCKET", ""),
-			Region:    getEnv("DO_SPACES_REGION", ""),
-			AccessKey: getEnv("DO_SPACES_KEY", ""),
-			SecretKey: getEnv("DO_SPACES_SECRET", ""),
-			CdnURL:    getEnv("DO_SPACES_CDN_URL", ""),
-		},
-	}
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getEnvBool(key string, defaultValue bool) bool {
-	value := strings.TrimSpace(strings.ToLower(os.Getenv(key)))
-	if value == "" {
-		return defaultValue
-	}
-	switch value {
-	case "1", "true":
-		return true
-	case "0", "false":
-		return false
-	default:
-		return defaultValue
-	}
-}
-
-func getEnvInt(key string, defaultValue int) int {
-	s := getEnv(key, "")
-	if s == "" {
-		return defaultValue
-	}
-	v, err := strconv.Atoi(s)
-	if err != nil {
-		return defaultValue
-	}
-	return v
-}
-
-
-func getEnvFloat64(key string, defaultValue float64) float64 {
-	value := strings.TrimSpace(os.Getenv(key))
-	if value == "" {
-		return defaultValue
-	}
-
-	parsed, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return defaultValue
-	}
-	return parsed
-}
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	Port          string
+	Environment   string
+	DatabaseURL   string
+	Store         StoreConfig
+	Search        SearchConfig
+	Views         ViewsConfig
+	Accounts      AccountsConfig
+	RateLimit     RateLimitConfig
+	DevBypassAuth bool
+	DevAuth0ID    string
+
+	// CURSOR_SIGNING_KEY — HMAC key for pagination cursors. Must be shared by all replicas.
+	CursorSigningKey string
+
+	// TRUSTED_PROXIES — comma-separated IPs/CIDRs of the load balancers in front of the API.
+	// Only they may set the client IP through X-Forwarded-For; empty trusts no proxy.
+	TrustedProxies []string
+
+	Auth0  Auth0Config
+	Spaces SpacesConfig
+	Concurrency ConcurrencyConfig
+
+}
+
+type StoreConfig struct {
+	SearchTrgmSimilarityThreshold float64
+}
+
+type SearchConfig struct {
+	UnifiedMaxConcurrentQueries int           // UNIFIED_SEARCH_MAX_CONCURRENT_QUERIES — DB queries /search may run at once, across all requests
+	UnifiedTimeout              time.Duration // UNIFIED_SEARCH_TIMEOUT_MS — deadline shared by every section of one /search request
+}
+
+type ViewsConfig struct {
+	DedupeWindow  time.Duration // VIEW_DEDUPE_WINDOW_MINS — a viewer counts at most once per video per window
+	FlushInterval time.Duration // VIEW_FLUSH_INTERVAL_SECS — how often buffered views are written to the DB
+}
+
+type AccountsConfig struct {
+	DeletionGracePeriod time.Duration // ACCOUNT_DELETION_GRACE_DAYS — how long a deleted account waits before it is purged
+	PurgeInterval       time.Duration // ACCOUNT_PURGE_INTERVAL_MINS — how often the purge job looks for due accounts
+	DataExportTTL       time.Duration // DATA_EXPORT_TTL_HOURS — how long a data export archive and its links stay available (max 7 days)
+}
+
+type RateLimitConfig struct {
+	Backend string    // RATE_LIMIT_BACKEND — memory (per replica) or postgres (shared by all replicas)
+	Upload  RateLimit // RATE_LIMIT_UPLOAD_* — POST /videos/upload/init, per user
+	Search  RateLimit // RATE_LIMIT_SEARCH_* — search endpoints, per user or client IP
+}
+
+// Rate limiter backends
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+// RateLimit is one token bucket: Burst requests at once, refilling at PerMinute.
+type RateLimit struct {
+	PerMinute int // *_PER_MIN — sustained requests per minute; 0 turns the limit off
+	Burst     int // *_BURST — bucket size
+}
+
+type Auth0Config struct {
+	Domain   string
+	Audience string
+}
+
+type SpacesConfig struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	CdnURL    string
+
+	PlaybackURLTTL     time.Duration // PLAYBACK_URL_TTL_MINS — lifetime of presigned URLs handed out for non-public videos
+	StripVideoLocation bool          // STRIP_VIDEO_LOCATION — serve a remuxed copy of each video without GPS metadata
+}
+
+type ConcurrencyConfig struct {
+	Concurrency          int           // POOL_CONCURRENCY — number of worker goroutines
+	QueueSize            int           // POOL_QUEUE_SIZE — job channel buffer size
+	SchedulerInterval    time.Duration // SCHEDULER_INTERVAL_SECS — how often scheduler polls DB
+	StuckThreshold       time.Duration // STUCK_THRESHOLD_MINS — how long before a processing job is considered stuck
+	ResetInterval        time.Duration // RESET_INTERVAL_MINS — how often the stuck-job reset loop runs
+	StatsRefreshInterval time.Duration // STATS_REFRESH_INTERVAL_MINS — how often materialized stats views are refreshed
+}
+
+func Load() *Config {
+	godotenv.Load()
+
+	return &Config{
+		Port:          getEnv("PORT", "8081"),
+		Environment:   getEnv("ENVIRONMENT", "development"),
+		DatabaseURL:   getEnv("DATABASE_URL", ""),
+		DevBypassAuth: getEnvBool("DEV_BYPASS_AUTH", false),
+		DevAuth0ID:    getEnv("DEV_AUTH0_ID", ""),
+
+		CursorSigningKey: getEnv("CURSOR_SIGNING_KEY", ""),
+		TrustedProxies:   getEnvList("TRUSTED_PROXIES"),
+
+		Store: StoreConfig{
+			SearchTrgmSimilarityThreshold: getEnvFloat64("SEARCH_TRGM_SIMILARITY_THRESHOLD", 0.3),
+		},
+
+		Search: SearchConfig{
+			UnifiedMaxConcurrentQueries: getEnvInt("UNIFIED_SEARCH_MAX_CONCURRENT_QUERIES", 8),
+			UnifiedTimeout:              time.Duration(getEnvInt("UNIFIED_SEARCH_TIMEOUT_MS", 1500)) * time.Millisecond,
+		},
+
+		Views: ViewsConfig{
+			DedupeWindow:  time.Duration(getEnvInt("VIEW_DEDUPE_WINDOW_MINS", 30)) * time.Minute,
+			FlushInterval: time.Duration(getEnvInt("VIEW_FLUSH_INTERVAL_SECS", 10)) * time.Second,
+		},
+
+		Accounts: AccountsConfig{
+			DeletionGracePeriod: time.Duration(getEnvInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour,
+			PurgeInterval:       time.Duration(getEnvInt("ACCOUNT_PURGE_INTERVAL_MINS", 60)) * time.Minute,
+			DataExportTTL:       time.Duration(getEnvInt("DATA_EXPORT_TTL_HOURS", 72)) * time.Hour,
+		},
+
+		RateLimit: RateLimitConfig{
+			Backend: getEnv("RATE_LIMIT_BACKEND", RateLimitBackendMemory),
+			Upload: RateLimit{
+				PerMinute: getEnvInt("RATE_LIMIT_UPLOAD_PER_MIN", 10),
+				Burst:     getEnvInt("RATE_LIMIT_UPLOAD_BURST", 20),
+			},
+			Search: RateLimit{
+				PerMinute: getEnvInt("RATE_LIMIT_SEARCH_PER_MIN", 60),
+				Burst:     getEnvInt("RATE_LIMIT_SEARCH_BURST", 20),
+			},
+		},
+
+		Concurrency: ConcurrencyConfig{
+			Concurrency:          getEnvInt("POOL_CONCURRENCY", 5),
+			QueueSize:            getEnvInt("POOL_QUEUE_SIZE", 50),
+			SchedulerInterval:    time.Duration(getEnvInt("SCHEDULER_INTERVAL_SECS", 30)) * time.Second,
+			StuckThreshold:       time.Duration(getEnvInt("STUCK_THRESHOLD_MINS", 10)) * time.Minute,
+			ResetInterval:        time.Duration(getEnvInt("RESET_INTERVAL_MINS", 5)) * time.Minute,
+			StatsRefreshInterval: time.Duration(getEnvInt("STATS_REFRESH_INTERVAL_MINS", 15)) * time.Minute,
+		},
+		
+		Auth0: Auth0Config{
+			Domain:   getEnv("AUTH0_DOMAIN", ""),
+			Audience: getEnv("AUTH0_AUDIENCE", ""),
+		},
+
+		Spaces: SpacesConfig{
+			Endpoint:  getEnv("DO_SPACES_ENDPOINT", ""),
+			Bucket:    getEnv("DO_SPACES_BUCKET", ""),
+			Region:    getEnv("DO_SPACES_REGION", ""),
+			AccessKey: getEnv("DO_SPACES_KEY", ""),
+			SecretKey: getEnv("DO_SPACES_SECRET", ""),
+			CdnURL:    getEnv("DO_SPACES_CDN_URL", ""),
+
+			PlaybackURLTTL:     time.Duration(getEnvInt("PLAYBACK_URL_TTL_MINS", 60)) * time.Minute,
+			StripVideoLocation: getEnvBool("STRIP_VIDEO_LOCATION", false),
+		},
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value := strings.TrimSpace(strings.ToLower(os.Getenv(key)))
+	if value == "" {
+		return defaultValue
+	}
+	switch value {
+	case "1", "true":
+		return true
+	case "0", "false":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+// getEnvList splits a comma-separated variable, dropping blank entries. Unset gives nil.
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	s := getEnv(key, "")
+	if s == "" {
+		return defaultValue
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+
+func getEnvFloat64(key string, defaultValue float64) float64 {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
@@ -2,10 +2,16 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 )
 
+const (
+	minCursorSigningKeyLength = 32
+	maxPresignTTL             = 7 * 24 * time.Hour // longest expiry S3 allows on a presigned URL
+)
+
 func (c *Config) Validate() error {
 	if c.DevBypassAuth {
 		if c.Environment != "development" {
@@ -16,9 +22,46 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Environment != "development" && len(c.CursorSigningKey) < minCursorSigningKeyLength {
+		return apperr.ErrCursorSigningKeyTooShort
+	}
+
 	if c.Store.SearchTrgmSimilarityThreshold < 0 || c.Store.SearchTrgmSimilarityThreshold > 1 {
 		return apperr.ErrInvalidSearchTrgmSimilarityThreshold
 	}
 
+	if c.Search.UnifiedTimeout <= 0 {
+		return apperr.ErrInvalidUnifiedSearchTimeout
+	}
+
+	if c.Views.FlushInterval <= 0 {
+		return apperr.ErrInvalidViewFlushInterval
+	}
+
+	if c.Concurrency.StatsRefreshInterval <= 0 {
+		return apperr.ErrInvalidStatsRefreshInterval
+	}
+
+	if c.Spaces.PlaybackURLTTL <= 0 || c.Spaces.PlaybackURLTTL > maxPresignTTL {
+		return apperr.ErrInvalidPlaybackURLTTL
+	}
+
+	if c.Accounts.PurgeInterval <= 0 {
+		return apperr.ErrInvalidAccountPurgeInterval
+	}
+
+	if c.Accounts.DataExportTTL <= 0 || c.Accounts.DataExportTTL > maxPresignTTL {
+		return apperr.ErrInvalidDataExportTTL
+	}
+
+	if c.RateLimit.Backend != RateLimitBackendMemory && c.RateLimit.Backend != RateLimitBackendPostgres {
+		return apperr.ErrInvalidRateLimitBackend
+	}
+	for _, limit := range []RateLimit{c.RateLimit.Upload, c.RateLimit.Search} {
+		if limit.PerMinute < 0 || (limit.PerMinute > 0 && limit.Burst < 1) {
+			return apperr.ErrInvalidRateLimit
+		}
+	}
+
 	return nil
 }
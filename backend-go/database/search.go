@@ -0,0 +1,86 @@
+package database
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SearchKeyset is the position of a search row in its ranking order, used for keyset pagination.
+//
+// Every search query ranks by the same leading tuple:
+//
+//	rank_exact DESC, rank_prefix DESC, rank_similarity DESC
+//
+// followed by an entity-specific tiebreaker. Only the tiebreaker fields an entity
+// orders by are populated (artists/songs: Verified + Label, concerts: Date,
+// users/venues: Label); ID is always last so the order is total.
+//
+// rank_exact and rank_prefix are small ints rather than booleans so entities
+// that match several columns (username, display_name) can fold them into one
+// value without changing the original ordering.
+type SearchKeyset struct {
+	Exact      int       `json:"e"`
+	Prefix     int       `json:"p"`
+	Similarity float32   `json:"s"` // similarity() returns real; float32 round-trips it exactly
+	Verified   bool      `json:"v,omitempty"`
+	Label      string    `json:"l,omitempty"`
+	Date       time.Time `json:"d,omitempty"`
+	ID         int       `json:"id"`
+}
+
+// SearchPage is one page of ranked search results.
+type SearchPage[T any] struct {
+	Items []T
+	Keys  []SearchKeyset // ranking position of each item, parallel to Items
+	Next  *SearchKeyset  // keyset of the last item when another page exists, nil on the final page
+}
+
+// scanRanked scans search rows shaped as entity columns followed by
+// rank_exact, rank_prefix, rank_similarity. tiebreak copies the entity's
+// tiebreaker fields into its keyset.
+//
+// Callers fetch maxResults+1 rows; the extra row only signals that another page exists.
+func scanRanked[T any](
+	rows pgx.Rows,
+	maxResults int,
+	fields func(*T) []any,
+	tiebreak func(*T, *SearchKeyset),
+) (*SearchPage[T], error) {
+	defer rows.Close()
+	page := &SearchPage[T]{
+		Items: make([]T, 0, maxResults),
+		Keys:  make([]SearchKeyset, 0, maxResults),
+	}
+	for rows.Next() {
+		var item T
+		var k SearchKeyset
+		dest := append(fields(&item), &k.Exact, &k.Prefix, &k.Similarity)
+		if err := rows.Scan(dest...); err != nil {
+			continue
+		}
+		tiebreak(&item, &k)
+		page.Items = append(page.Items, item)
+		page.Keys = append(page.Keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Items) > maxResults {
+		page.Items = page.Items[:maxResults]
+		page.Keys = page.Keys[:maxResults]
+		page.Next = &page.Keys[maxResults-1]
+	}
+	return page, nil
+}
+
+// keysetStart splits an optional keyset into the rank_exact query argument
+// (NULL on the first page, which every search query treats as "no cursor")
+// and a non-nil keyset to read the remaining arguments from.
+func keysetStart(after *SearchKeyset) (*int, SearchKeyset) {
+	if after == nil {
+		return nil, SearchKeyset{}
+	}
+	return &after.Exact, *after
+}
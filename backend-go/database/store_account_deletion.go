@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// purgedCommentBody replaces the body of every comment a purged account wrote.
+const purgedCommentBody = "[deleted]"
+
+// SoftDeleteUser marks a user deleted and hides all of their videos. The account is
+// purged by the account deletion job once the grace period is over.
+func (s *Store) SoftDeleteUser(ctx context.Context, userID int) error {
+	const q = `
+	WITH deleted_user AS (
+		UPDATE users SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id
+	),
+	hidden_videos AS (
+		UPDATE videos SET deleted_at = NOW(), updated_at = NOW()
+		WHERE user_id IN (SELECT id FROM deleted_user) AND deleted_at IS NULL
+	)
+	SELECT COUNT(*) FROM deleted_user`
+
+	var deleted int
+	if err := s.pool.QueryRow(ctx, q, userID).Scan(&deleted); err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// ListVideosHiddenByDeletion returns the uploaded videos SoftDeleteUser hid for a deleted user.
+func (s *Store) ListVideosHiddenByDeletion(ctx context.Context, userID int) ([]*models.Video, error) {
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE user_id = $1
+	  AND status = $2
+	  AND deleted_at = (SELECT deleted_at FROM users WHERE id = $1)
+	ORDER BY id`
+
+	rows, err := s.pool.Query(ctx, q, userID, models.VideoStatusCompleted)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, false)
+}
+
+// RestoreDeletedUser undoes SoftDeleteUser for an account whose purge hasn't started and
+// returns its ID. Videos the deletion hid come back; ones the user deleted earlier stay deleted.
+func (s *Store) RestoreDeletedUser(ctx context.Context, username string) (int, error) {
+	const q = `
+	WITH target AS (
+		SELECT id, deleted_at FROM users
+		WHERE LOWER(username) = LOWER($1)
+		  AND deleted_at IS NOT NULL
+		  AND purge_started_at IS NULL
+		  AND purged_at IS NULL
+		FOR UPDATE
+	),
+	restored_user AS (
+		UPDATE users u SET deleted_at = NULL, updated_at = NOW()
+		FROM target t
+		WHERE u.id = t.id
+		RETURNING u.id
+	),
+	restored_videos AS (
+		UPDATE videos v SET deleted_at = NULL, updated_at = NOW()
+		FROM target t
+		WHERE v.user_id = t.id AND v.deleted_at = t.deleted_at
+	)
+	SELECT id FROM restored_user`
+
+	var userID int
+	err := s.pool.QueryRow(ctx, q, username).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, apperr.ErrNotFound
+	}
+	return userID, err
+}
+
+// ClaimAccountPurges leases up to limit accounts deleted before deletedBefore for purging
+// and returns their IDs. Accounts whose lease started before leaseExpiredBefore are
+// claimable again, so a purge that died midway is picked back up.
+func (s *Store) ClaimAccountPurges(ctx context.Context, deletedBefore time.Time, leaseExpiredBefore time.Time, limit int) ([]int, error) {
+	const q = `
+	UPDATE users SET purge_started_at = NOW()
+	WHERE id IN (
+		SELECT id FROM users
+		WHERE deleted_at < $1 AND purged_at IS NULL
+		  AND (purge_started_at IS NULL OR purge_started_at < $2)
+		ORDER BY deleted_at, id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id`
+
+	rows, err := s.pool.Query(ctx, q, deletedBefore, leaseExpiredBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// ListVideosForPurge returns up to limit of a user's videos, deleted ones included.
+func (s *Store) ListVideosForPurge(ctx context.Context, userID int, limit int) ([]*models.Video, error) {
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE user_id = $1
+	ORDER BY id
+	LIMIT $2`
+
+	rows, err := s.pool.Query(ctx, q, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, false)
+}
+
+// PurgeVideo hard-deletes a video and the comments on it. Reactions and notifications
+// about the video cascade with it.
+func (s *Store) PurgeVideo(ctx context.Context, videoID int) error {
+	const q = `
+	WITH purged_comments AS (
+		DELETE FROM comments WHERE target_type = $1 AND target_id = $2
+	)
+	DELETE FROM videos WHERE id = $2`
+
+	_, err := s.pool.Exec(ctx, q, models.CommentTargetVideo, videoID)
+	return err
+}
+
+// ScrubUserComments blanks and deletes every comment the user wrote, keeping the rows
+// so other users' replies stay threaded, then recounts the replies of the threads the
+// user replied in. Safe to re-run.
+func (s *Store) ScrubUserComments(ctx context.Context, userID int) error {
+	const scrub = `
+	WITH scrubbed AS (
+		UPDATE comments
+		SET body = $2, deleted_at = COALESCE(deleted_at, NOW()), updated_at = NOW()
+		WHERE user_id = $1 AND body <> $2
+		RETURNING id
+	)
+	DELETE FROM comment_mentions WHERE comment_id IN (SELECT id FROM scrubbed)`
+
+	if _, err := s.pool.Exec(ctx, scrub, userID, purgedCommentBody); err != nil {
+		return err
+	}
+
+	const recount = `
+	UPDATE comments p
+	SET reply_count = (
+		SELECT COUNT(*) FROM comments r
+		WHERE r.parent_id = p.id AND r.deleted_at IS NULL
+	)
+	WHERE p.id IN (
+		SELECT parent_id FROM comments
+		WHERE user_id = $1 AND parent_id IS NOT NULL
+	)`
+
+	_, err := s.pool.Exec(ctx, recount, userID)
+	return err
+}
+
+// CompleteAccountPurge removes the rest of a deleted user's data, detaches their catalog
+// contributions, and scrubs the users row down to an anonymous tombstone. The Auth0
+// identities are released, so signing in again creates a fresh account.
+func (s *Store) CompleteAccountPurge(ctx context.Context, userID int) error {
+	const q = `
+	WITH
+	reactions AS (DELETE FROM video_reactions WHERE user_id = $1),
+	artist_follows AS (DELETE FROM artist_follows WHERE user_id = $1),
+	attendance AS (DELETE FROM concert_attendance WHERE user_id = $1),
+	user_follows AS (DELETE FROM user_follows WHERE follower_id = $1 OR followee_id = $1),
+	blocks AS (DELETE FROM user_blocks WHERE blocker_id = $1 OR blocked_id = $1),
+	mentions AS (DELETE FROM comment_mentions WHERE user_id = $1),
+	reports AS (DELETE FROM comment_reports WHERE reporter_id = $1),
+	notifications AS (DELETE FROM notifications WHERE user_id = $1 OR actor_id = $1),
+	exports AS (DELETE FROM data_exports WHERE user_id = $1),
+	identities AS (DELETE FROM user_identities WHERE user_id = $1),
+	link_codes AS (DELETE FROM account_link_codes WHERE user_id = $1),
+	tokens AS (DELETE FROM personal_access_tokens WHERE user_id = $1),
+	artists AS (UPDATE artists SET created_by_user_id = NULL WHERE created_by_user_id = $1),
+	songs AS (UPDATE songs SET created_by_user_id = NULL WHERE created_by_user_id = $1)
+	UPDATE users SET
+		auth0_id = 'deleted|' || id,
+		email = 'deleted-' || id || '@deleted.invalid',
+		username = 'deleted-' || id,
+		display_name = 'Deleted user',
+		profile_picture = NULL,
+		bio = NULL,
+		role = $2,
+		notification_preferences = '{}',
+		purged_at = NOW(),
+		updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	_, err := s.pool.Exec(ctx, q, userID, models.RoleUser)
+	return err
+}
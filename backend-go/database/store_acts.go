@@ -63,3 +63,23 @@ func (s *Store) ListActsByConcert(ctx context.Context, concertID int) ([]models.
 
 	return scanActs(rows, true)
 }
+
+// ListActsByConcertIDs returns the acts of several concerts, in creation order within each concert.
+func (s *Store) ListActsByConcertIDs(ctx context.Context, concertIDs []int) ([]models.Act, error) {
+	if len(concertIDs) == 0 {
+		return []models.Act{}, nil
+	}
+
+	const q = `
+	SELECT ` + actCols + `
+	FROM acts
+	WHERE concert_id = ANY($1::int[]) AND deleted_at IS NULL
+	ORDER BY concert_id, created_at ASC, id ASC`
+
+	rows, err := s.pool.Query(ctx, q, concertIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanActs(rows, true)
+}
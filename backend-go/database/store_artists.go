@@ -22,9 +22,9 @@ const artistCols = `
 	deleted_at
 `
 
-func scanArtist(row pgx.Row) (*models.Artist, error) {
-	var a models.Artist
-	err := row.Scan(
+// artistFields returns scan destinations for artistCols, in column order.
+func artistFields(a *models.Artist) []any {
+	return []any{
 		&a.ID,
 		&a.Name,
 		&a.MusicBrainzID,
@@ -35,7 +35,12 @@ func scanArtist(row pgx.Row) (*models.Artist, error) {
 		&a.CreatedByUserID,
 		&a.CreatedAt,
 		&a.DeletedAt,
-	)
+	}
+}
+
+func scanArtist(row pgx.Row) (*models.Artist, error) {
+	var a models.Artist
+	err := row.Scan(artistFields(&a)...)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, apperr.ErrNotFound
 	}
@@ -70,6 +75,21 @@ func (s *Store) GetArtistByID(ctx context.Context, id int) (*models.Artist, erro
 	return scanArtist(s.pool.QueryRow(ctx, q, id))
 }
 
+func (s *Store) ArtistExists(ctx context.Context, artistID int) (bool, error) {
+	const q = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM artists
+		WHERE id = $1 AND deleted_at IS NULL
+	)`
+
+	var exists bool
+	if err := s.pool.QueryRow(ctx, q, artistID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
 func (s *Store) ListArtistsByIDs(ctx context.Context, ids []int) ([]models.Artist, error) {
 	if len(ids) == 0 {
 		return []models.Artist{}, nil
@@ -89,26 +109,47 @@ func (s *Store) ListArtistsByIDs(ctx context.Context, ids []int) ([]models.Artis
 	return scanArtists(rows, true)
 }
 
-func (s *Store) SearchArtists(ctx context.Context, query string, maxResults int) ([]models.Artist, error) {
+// SearchArtists returns one page of artists matching query, with each row's ranking position.
+func (s *Store) SearchArtists(ctx context.Context, query string, after *SearchKeyset, maxResults int) (*SearchPage[models.Artist], error) {
 	query, likeQuery := prepareSearchQuery(query)
+	afterExact, k := keysetStart(after)
 
-	const q = `
-	SELECT ` + artistCols + `
-	FROM artists
-	WHERE deleted_at IS NULL
-	  AND (name ILIKE $2 OR similarity(name, $1) >= $4)
+	q := `
+	WITH ranked AS (
+		SELECT ` + artistCols + `,
+		  (lower(name) = lower($1))::int AS rank_exact,
+		  (name ILIKE $1 || '%')::int AS rank_prefix,
+		  similarity(name, $1) AS rank_similarity
+		FROM artists
+		WHERE deleted_at IS NULL
+		  AND (name ILIKE $2 OR similarity(name, $1) >= $4)
+	)
+	SELECT ` + artistCols + `, rank_exact, rank_prefix, rank_similarity
+	FROM ranked
+	WHERE $5::int IS NULL
+	   OR (rank_exact, rank_prefix, rank_similarity, is_verified) < ($5, $6::int, $7::real, $8::boolean)
+	   OR (
+	     (rank_exact, rank_prefix, rank_similarity, is_verified) = ($5, $6::int, $7::real, $8::boolean)
+	     AND (name, id) > ($9::text, $10::int)
+	   )
 	ORDER BY
-	  (lower(name) = lower($1)) DESC,
-	  (name ILIKE $1 || '%') DESC,
-	  similarity(name, $1) DESC,
+	  rank_exact DESC,
+	  rank_prefix DESC,
+	  rank_similarity DESC,
 	  is_verified DESC,
-	  name ASC
+	  name ASC,
+	  id ASC
 	LIMIT $3`
 
-	rows, err := s.pool.Query(ctx, q, query, likeQuery, maxResults, s.searchTrgmSimilarityThreshold)
+	rows, err := s.pool.Query(ctx, q,
+		query, likeQuery, maxResults+1, s.searchTrgmSimilarityThreshold,
+		afterExact, k.Prefix, k.Similarity, k.Verified, k.Label, k.ID,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return scanArtists(rows, true)
+	return scanRanked(rows, maxResults, artistFields, func(a *models.Artist, k *SearchKeyset) {
+		k.Verified, k.Label, k.ID = a.IsVerified, a.Name, a.ID
+	})
 }
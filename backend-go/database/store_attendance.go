@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const attendanceCols = `
+	user_id,
+	concert_id,
+	source,
+	visibility,
+	created_at
+`
+
+func scanAttendance(row pgx.Row) (*models.Attendance, error) {
+	var a models.Attendance
+	if err := row.Scan(
+		&a.UserID,
+		&a.ConcertID,
+		&a.Source,
+		&a.Visibility,
+		&a.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+// UpsertAttendance marks a user as attending a concert.
+// An existing record keeps its original source and created_at; visibility is
+// only changed when one is given.
+func (s *Store) UpsertAttendance(ctx context.Context, userID int, concertID int, source string, visibility *string) (*models.Attendance, error) {
+	const q = `
+	INSERT INTO concert_attendance (user_id, concert_id, source, visibility)
+	VALUES ($1, $2, $3, COALESCE($4, $5))
+	ON CONFLICT (user_id, concert_id) DO UPDATE
+	SET visibility = COALESCE($4, concert_attendance.visibility)
+	RETURNING ` + attendanceCols
+
+	return scanAttendance(s.pool.QueryRow(ctx, q, userID, concertID, source, visibility, models.AttendanceVisibilityPublic))
+}
+
+// RecordAttendance creates an attendance record if the user has none for the concert.
+// Used by automatic paths (uploads, detection), which must never override a user's choices.
+func (s *Store) RecordAttendance(ctx context.Context, userID int, concertID int, source string) error {
+	const q = `
+	INSERT INTO concert_attendance (user_id, concert_id, source)
+	SELECT $1, $2, $3
+	WHERE EXISTS (SELECT 1 FROM concerts WHERE id = $2 AND deleted_at IS NULL)
+	ON CONFLICT (user_id, concert_id) DO NOTHING`
+
+	_, err := s.pool.Exec(ctx, q, userID, concertID, source)
+	return err
+}
+
+// DeleteAttendance removes a user's attendance record. Removing a missing record is not an error.
+func (s *Store) DeleteAttendance(ctx context.Context, userID int, concertID int) error {
+	const q = `
+	DELETE FROM concert_attendance
+	WHERE user_id = $1 AND concert_id = $2`
+
+	_, err := s.pool.Exec(ctx, q, userID, concertID)
+	return err
+}
+
+// GetAttendance returns a user's attendance record for a concert, or apperr.ErrNotFound.
+func (s *Store) GetAttendance(ctx context.Context, userID int, concertID int) (*models.Attendance, error) {
+	const q = `
+	SELECT ` + attendanceCols + `
+	FROM concert_attendance
+	WHERE user_id = $1 AND concert_id = $2`
+
+	return scanAttendance(s.pool.QueryRow(ctx, q, userID, concertID))
+}
+
+// ListAttendeesByConcert pages through a concert's attendees, most recently recorded first.
+// Private records are only included for viewerID themselves (0 for anonymous viewers).
+// afterCreatedAt/afterUserID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListAttendeesByConcert(ctx context.Context, concertID int, viewerID int, afterCreatedAt *time.Time, afterUserID int, limit int) ([]models.Attendee, error) {
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `, ca.created_at
+	FROM concert_attendance ca
+	JOIN users u ON u.id = ca.user_id AND u.deleted_at IS NULL
+	WHERE ca.concert_id = $1
+	  AND (ca.visibility = $2 OR ca.user_id = $3)
+	  AND ($4::timestamp IS NULL OR (ca.created_at, ca.user_id) < ($4, $5))
+	ORDER BY ca.created_at DESC, ca.user_id DESC
+	LIMIT $6`
+
+	rows, err := s.pool.Query(ctx, q, concertID, models.AttendanceVisibilityPublic, viewerID, afterCreatedAt, afterUserID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attendees := make([]models.Attendee, 0)
+	for rows.Next() {
+		var a models.Attendee
+		if err := rows.Scan(append(userFields(&a.User), &a.AttendedAt)...); err != nil {
+			continue
+		}
+		attendees = append(attendees, a)
+	}
+	return attendees, rows.Err()
+}
+
+// ListAttendedConcertsByUser pages through concerts a user attended, most recent concert first.
+// Private records are skipped unless includePrivate is set (the user viewing their own list).
+// afterDate/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListAttendedConcertsByUser(ctx context.Context, userID int, includePrivate bool, afterDate *time.Time, afterID int, limit int) ([]models.Concert, error) {
+	qualifiedCols, err := qualifyColumns("c", concertCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build concert columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `
+	FROM concert_attendance ca
+	JOIN concerts c ON c.id = ca.concert_id AND c.deleted_at IS NULL
+	WHERE ca.user_id = $1
+	  AND ($2 OR ca.visibility = $3)
+	  AND ($4::timestamp IS NULL OR (c.date, c.id) < ($4, $5))
+	ORDER BY c.date DESC, c.id DESC
+	LIMIT $6`
+
+	rows, err := s.pool.Query(ctx, q, userID, includePrivate, models.AttendanceVisibilityPublic, afterDate, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanConcerts(rows, true)
+}
+
+// ListAttendanceByUser returns every attendance record of a user, private ones included, oldest first.
+func (s *Store) ListAttendanceByUser(ctx context.Context, userID int) ([]models.Attendance, error) {
+	const q = `
+	SELECT ` + attendanceCols + `
+	FROM concert_attendance
+	WHERE user_id = $1
+	ORDER BY created_at, concert_id`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]models.Attendance, 0)
+	for rows.Next() {
+		a, err := scanAttendance(rows)
+		if err != nil {
+			continue
+		}
+		records = append(records, *a)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+)
+
+// BlockUser makes blockerID block blockedID and removes any follows between them.
+// Blocking is idempotent.
+func (s *Store) BlockUser(ctx context.Context, blockerID int, blockedID int) error {
+	const q = `
+	WITH blocked AS (
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	)
+	DELETE FROM user_follows
+	WHERE (follower_id = $1 AND followee_id = $2)
+	   OR (follower_id = $2 AND followee_id = $1)`
+
+	_, err := s.pool.Exec(ctx, q, blockerID, blockedID)
+	return err
+}
+
+// UnblockUser removes blockerID's block of blockedID. Unblocking is idempotent.
+func (s *Store) UnblockUser(ctx context.Context, blockerID int, blockedID int) error {
+	const q = `
+	DELETE FROM user_blocks
+	WHERE blocker_id = $1 AND blocked_id = $2`
+
+	_, err := s.pool.Exec(ctx, q, blockerID, blockedID)
+	return err
+}
+
+// IsBlockedEitherWay reports whether either user has blocked the other.
+func (s *Store) IsBlockedEitherWay(ctx context.Context, userID int, otherID int) (bool, error) {
+	const q = `
+	SELECT EXISTS (
+		SELECT 1 FROM user_blocks
+		WHERE (blocker_id = $1 AND blocked_id = $2)
+		   OR (blocker_id = $2 AND blocked_id = $1)
+	)`
+
+	var blocked bool
+	err := s.pool.QueryRow(ctx, q, userID, otherID).Scan(&blocked)
+	return blocked, err
+}
+
+// ListBlockedEitherWayIDs returns which of userIDs have blocked userID or been blocked by them.
+func (s *Store) ListBlockedEitherWayIDs(ctx context.Context, userID int, userIDs []int) ([]int, error) {
+	if len(userIDs) == 0 {
+		return []int{}, nil
+	}
+
+	const q = `
+	SELECT blocked_id FROM user_blocks
+	WHERE blocker_id = $1 AND blocked_id = ANY($2::int[])
+	UNION
+	SELECT blocker_id FROM user_blocks
+	WHERE blocked_id = $1 AND blocker_id = ANY($2::int[])`
+
+	rows, err := s.pool.Query(ctx, q, userID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
@@ -0,0 +1,383 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const commentCols = `
+	id,
+	user_id,
+	target_type,
+	target_id,
+	parent_id,
+	body,
+	reply_count,
+	edited_at,
+	created_at,
+	updated_at,
+	deleted_at
+`
+
+// commentFields returns scan destinations for commentCols, in column order.
+func commentFields(c *models.Comment) []any {
+	return []any{
+		&c.ID,
+		&c.UserID,
+		&c.TargetType,
+		&c.TargetID,
+		&c.ParentID,
+		&c.Body,
+		&c.ReplyCount,
+		&c.EditedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.DeletedAt,
+	}
+}
+
+func scanComment(row pgx.Row) (*models.Comment, error) {
+	var c models.Comment
+	if err := row.Scan(commentFields(&c)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func scanComments(rows pgx.Rows, allowPartial bool) ([]models.Comment, error) {
+	defer rows.Close()
+	comments := make([]models.Comment, 0)
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			if allowPartial {
+				continue
+			}
+			return comments, err
+		}
+		comments = append(comments, *c)
+	}
+	return comments, rows.Err()
+}
+
+// notBlockedBetween filters comments (alias c) to those whose author and the viewer
+// ($n, 0 when anonymous) have not blocked each other.
+func notBlockedBetween(param string) string {
+	return `($` + param + `::int = 0 OR NOT EXISTS (
+		SELECT 1 FROM user_blocks b
+		WHERE (b.blocker_id = $` + param + ` AND b.blocked_id = c.user_id)
+		   OR (b.blocker_id = c.user_id AND b.blocked_id = $` + param + `)
+	))`
+}
+
+// CreateComment inserts a comment with its mentions, bumping the parent's reply count for replies.
+func (s *Store) CreateComment(ctx context.Context, userID int, targetType string, targetID int, parentID *int, body string, mentionIDs []int) (*models.Comment, error) {
+	const q = `
+	WITH created AS (
+		INSERT INTO comments (user_id, target_type, target_id, parent_id, body)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + commentCols + `
+	),
+	mentions AS (
+		INSERT INTO comment_mentions (comment_id, user_id)
+		SELECT created.id, m.user_id
+		FROM created, unnest($6::int[]) AS m(user_id)
+		ON CONFLICT DO NOTHING
+	),
+	parent AS (
+		UPDATE comments
+		SET reply_count = reply_count + 1
+		WHERE id = $4
+	)
+	SELECT ` + commentCols + ` FROM created`
+
+	return scanComment(s.pool.QueryRow(ctx, q, userID, targetType, targetID, parentID, body, mentionIDs))
+}
+
+// GetCommentByID returns a comment, including soft-deleted ones (callers check DeletedAt):
+// a deleted top-level comment can still anchor a thread of replies.
+func (s *Store) GetCommentByID(ctx context.Context, commentID int) (*models.Comment, error) {
+	const q = `
+	SELECT ` + commentCols + `
+	FROM comments
+	WHERE id = $1`
+
+	return scanComment(s.pool.QueryRow(ctx, q, commentID))
+}
+
+// UpdateCommentBody replaces a live comment's body and mentions and marks it edited.
+func (s *Store) UpdateCommentBody(ctx context.Context, commentID int, body string, mentionIDs []int) (*models.Comment, error) {
+	const q = `
+	WITH updated AS (
+		UPDATE comments
+		SET body = $2, edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + commentCols + `
+	),
+	removed AS (
+		DELETE FROM comment_mentions
+		WHERE comment_id IN (SELECT id FROM updated)
+		  AND user_id <> ALL($3::int[])
+	),
+	added AS (
+		INSERT INTO comment_mentions (comment_id, user_id)
+		SELECT updated.id, m.user_id
+		FROM updated, unnest($3::int[]) AS m(user_id)
+		ON CONFLICT DO NOTHING
+	)
+	SELECT ` + commentCols + ` FROM updated`
+
+	return scanComment(s.pool.QueryRow(ctx, q, commentID, body, mentionIDs))
+}
+
+// SoftDeleteComment marks a comment deleted and, for replies, drops the parent's reply count.
+// Deleting an already-deleted comment is a no-op.
+func (s *Store) SoftDeleteComment(ctx context.Context, commentID int) error {
+	const q = `
+	WITH deleted AS (
+		UPDATE comments
+		SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING parent_id
+	)
+	UPDATE comments p
+	SET reply_count = GREATEST(p.reply_count - 1, 0)
+	FROM deleted
+	WHERE p.id = deleted.parent_id`
+
+	_, err := s.pool.Exec(ctx, q, commentID)
+	return err
+}
+
+// ListComments pages through a target's top-level comments, newest first, as seen by
+// viewerID (0 when anonymous). Deleted comments are kept only while they have replies,
+// and comments from users blocked either way with the viewer are hidden.
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListComments(ctx context.Context, targetType string, targetID int, viewerID int, afterCreatedAt *time.Time, afterID int, limit int) ([]models.Comment, error) {
+	qualifiedCols, err := qualifyColumns("c", commentCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build comment columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `
+	FROM comments c
+	WHERE c.target_type = $1
+	  AND c.target_id = $2
+	  AND c.parent_id IS NULL
+	  AND (c.deleted_at IS NULL OR c.reply_count > 0)
+	  AND ` + notBlockedBetween("3") + `
+	  AND ($4::timestamp IS NULL OR (c.created_at, c.id) < ($4, $5))
+	ORDER BY c.created_at DESC, c.id DESC
+	LIMIT $6`
+
+	rows, err := s.pool.Query(ctx, q, targetType, targetID, viewerID, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanComments(rows, true)
+}
+
+// ListReplies pages through a comment's live replies, oldest first, as seen by viewerID
+// (0 when anonymous). Replies from users blocked either way with the viewer are hidden.
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListReplies(ctx context.Context, parentID int, viewerID int, afterCreatedAt *time.Time, afterID int, limit int) ([]models.Comment, error) {
+	qualifiedCols, err := qualifyColumns("c", commentCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build comment columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `
+	FROM comments c
+	WHERE c.parent_id = $1
+	  AND c.deleted_at IS NULL
+	  AND ` + notBlockedBetween("2") + `
+	  AND ($3::timestamp IS NULL OR (c.created_at, c.id) > ($3, $4))
+	ORDER BY c.created_at ASC, c.id ASC
+	LIMIT $5`
+
+	rows, err := s.pool.Query(ctx, q, parentID, viewerID, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanComments(rows, true)
+}
+
+// ListCommentMentions returns the active users mentioned by each of commentIDs.
+func (s *Store) ListCommentMentions(ctx context.Context, commentIDs []int) (map[int][]models.User, error) {
+	mentions := make(map[int][]models.User)
+	if len(commentIDs) == 0 {
+		return mentions, nil
+	}
+
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user columns: %w", err)
+	}
+
+	q := `
+	SELECT m.comment_id, ` + qualifiedCols + `
+	FROM comment_mentions m
+	JOIN users u ON u.id = m.user_id AND u.deleted_at IS NULL
+	WHERE m.comment_id = ANY($1::int[])
+	ORDER BY m.comment_id, u.username`
+
+	rows, err := s.pool.Query(ctx, q, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var commentID int
+		var u models.User
+		if err := rows.Scan(append([]any{&commentID}, userFields(&u)...)...); err != nil {
+			continue
+		}
+		mentions[commentID] = append(mentions[commentID], u)
+	}
+	return mentions, rows.Err()
+}
+
+// CreateCommentReport queues a report of a comment for moderation.
+// A user reporting the same comment twice gets apperr.ErrDuplicate.
+func (s *Store) CreateCommentReport(ctx context.Context, commentID int, reporterID int, reason string, details *string) (*models.CommentReport, error) {
+	const q = `
+	INSERT INTO comment_reports (comment_id, reporter_id, reason, details)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (comment_id, reporter_id) DO NOTHING
+	RETURNING ` + commentReportCols
+
+	var r models.CommentReport
+	err := s.pool.QueryRow(ctx, q, commentID, reporterID, reason, details).Scan(commentReportFields(&r)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrDuplicate
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+const commentReportCols = `
+	id,
+	comment_id,
+	reporter_id,
+	reason,
+	details,
+	status,
+	created_at,
+	resolved_at,
+	resolved_by
+`
+
+// commentReportFields returns scan destinations for commentReportCols, in column order.
+func commentReportFields(r *models.CommentReport) []any {
+	return []any{
+		&r.ID,
+		&r.CommentID,
+		&r.ReporterID,
+		&r.Reason,
+		&r.Details,
+		&r.Status,
+		&r.CreatedAt,
+		&r.ResolvedAt,
+		&r.ResolvedBy,
+	}
+}
+
+func (s *Store) GetCommentReportByID(ctx context.Context, reportID int) (*models.CommentReport, error) {
+	const q = `
+	SELECT ` + commentReportCols + `
+	FROM comment_reports
+	WHERE id = $1`
+
+	var r models.CommentReport
+	err := s.pool.QueryRow(ctx, q, reportID).Scan(commentReportFields(&r)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListCommentReports pages through reports with the given status, oldest first, each with
+// the comment it is about (deleted comments included).
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListCommentReports(ctx context.Context, status string, afterCreatedAt *time.Time, afterID int, limit int) ([]models.ReportedComment, error) {
+	reportCols, err := qualifyColumns("r", commentReportCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build comment report columns: %w", err)
+	}
+	qualifiedCommentCols, err := qualifyColumns("c", commentCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build comment columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + reportCols + `, ` + qualifiedCommentCols + `
+	FROM comment_reports r
+	JOIN comments c ON c.id = r.comment_id
+	WHERE r.status = $1
+	  AND ($2::timestamp IS NULL OR (r.created_at, r.id) > ($2, $3))
+	ORDER BY r.created_at ASC, r.id ASC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, status, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := make([]models.ReportedComment, 0)
+	for rows.Next() {
+		var rc models.ReportedComment
+		if err := rows.Scan(append(commentReportFields(&rc.Report), commentFields(&rc.Comment)...)...); err != nil {
+			continue
+		}
+		reports = append(reports, rc)
+	}
+	return reports, rows.Err()
+}
+
+// ResolveCommentReports closes every open report of a comment with the given status
+// and returns how many were closed.
+func (s *Store) ResolveCommentReports(ctx context.Context, commentID int, status string, resolvedBy int) (int, error) {
+	const q = `
+	UPDATE comment_reports
+	SET status = $1, resolved_at = NOW(), resolved_by = $2
+	WHERE comment_id = $3 AND status = $4`
+
+	tag, err := s.pool.Exec(ctx, q, status, resolvedBy, commentID, models.CommentReportStatusOpen)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// ListCommentsByUser returns every comment a user has written and not deleted, oldest first.
+func (s *Store) ListCommentsByUser(ctx context.Context, userID int) ([]models.Comment, error) {
+	const q = `
+	SELECT ` + commentCols + `
+	FROM comments
+	WHERE user_id = $1 AND deleted_at IS NULL
+	ORDER BY created_at, id`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanComments(rows, true)
+}
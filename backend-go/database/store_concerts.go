@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
@@ -20,10 +22,9 @@ const concertCols = `
 	deleted_at
 `
 
-func scanConcert(row pgx.Row) (*models.Concert, error) {
-	var c models.Concert
-
-	if err := row.Scan(
+// concertFields returns scan destinations for concertCols, in column order.
+func concertFields(c *models.Concert) []any {
+	return []any{
 		&c.ID,
 		&c.Name,
 		&c.Date,
@@ -32,7 +33,13 @@ func scanConcert(row pgx.Row) (*models.Concert, error) {
 		&c.SetlistFmID,
 		&c.CreatedAt,
 		&c.DeletedAt,
-	); err != nil {
+	}
+}
+
+func scanConcert(row pgx.Row) (*models.Concert, error) {
+	var c models.Concert
+
+	if err := row.Scan(concertFields(&c)...); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperr.ErrNotFound
 		}
@@ -82,29 +89,220 @@ func (s *Store) ConcertExists(ctx context.Context, concertID int) (bool, error)
 	return exists, nil
 }
 
-func (s *Store) SearchConcerts(ctx context.Context, query string, maxResults int) ([]models.Concert, error) {
+// ConcertSearchFilter narrows a concert search. Nil fields don't filter.
+// DateTo is inclusive of the whole day.
+type ConcertSearchFilter struct {
+	DateFrom    *time.Time
+	DateTo      *time.Time
+	City        *string // case-insensitive venue city
+	CountryCode *string // venue ISO country code
+	ArtistID    *int    // any act by the artist
+	HasVideos   *bool   // true: at least one public, uploaded video; false: none
+}
+
+// SearchConcerts returns one page of concerts matching query and filter, with each row's ranking position.
+// An empty query matches every concert that passes the filter; ranking then falls through to date.
+func (s *Store) SearchConcerts(ctx context.Context, query string, filter ConcertSearchFilter, after *SearchKeyset, maxResults int) (*SearchPage[models.Concert], error) {
 	query, likeQuery := prepareSearchQuery(query)
+	afterExact, k := keysetStart(after)
+
+	q := `
+	WITH ranked AS (
+		SELECT ` + concertCols + `,
+		  (lower(name) = lower($1))::int AS rank_exact,
+		  (name ILIKE $1 || '%')::int AS rank_prefix,
+		  similarity(name, $1) AS rank_similarity
+		FROM concerts c
+		WHERE deleted_at IS NULL
+		  AND (
+		    $1 = ''
+		    OR name ILIKE $2
+		    OR similarity(name, $1) >= $4
+		  )
+		  AND ($10::timestamp IS NULL OR date >= $10)
+		  AND ($11::timestamp IS NULL OR date < $11 + INTERVAL '1 day')
+		  AND (
+		    ($12::text IS NULL AND $13::text IS NULL)
+		    OR EXISTS (
+		      SELECT 1 FROM venues v
+		      WHERE v.id = c.venue_id
+		        AND v.deleted_at IS NULL
+		        AND ($12::text IS NULL OR lower(v.city) = lower($12))
+		        AND ($13::text IS NULL OR v.country_code = upper($13))
+		    )
+		  )
+		  AND (
+		    $14::int IS NULL
+		    OR EXISTS (
+		      SELECT 1 FROM acts a
+		      WHERE a.concert_id = c.id AND a.artist_id = $14 AND a.deleted_at IS NULL
+		    )
+		  )
+		  AND (
+		    $15::bool IS NULL
+		    OR $15 = EXISTS (
+		      SELECT 1 FROM videos vd
+		      WHERE vd.event_type = $16
+		        AND vd.event_id = c.id
+		        AND vd.status = $17
+		        AND vd.visibility = $18
+		        AND vd.deleted_at IS NULL
+		    )
+		  )
+	)
+	SELECT ` + concertCols + `, rank_exact, rank_prefix, rank_similarity
+	FROM ranked
+	WHERE $5::int IS NULL
+	   OR (rank_exact, rank_prefix, rank_similarity, date) < ($5, $6::int, $7::real, $8::timestamp)
+	   OR (
+	     (rank_exact, rank_prefix, rank_similarity, date) = ($5, $6::int, $7::real, $8::timestamp)
+	     AND id > $9::int
+	   )
+	ORDER BY
+	  rank_exact DESC,
+	  rank_prefix DESC,
+	  rank_similarity DESC,
+	  date DESC,
+	  id ASC
+	LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q,
+		query, likeQuery, maxResults+1, s.searchTrgmSimilarityThreshold,
+		afterExact, k.Prefix, k.Similarity, k.Date, k.ID,
+		filter.DateFrom, filter.DateTo, filter.City, filter.CountryCode, filter.ArtistID,
+		filter.HasVideos, models.EventTypeConcert, models.VideoStatusCompleted, models.VideoVisibilityPublic,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRanked(rows, maxResults, concertFields, func(c *models.Concert, k *SearchKeyset) {
+		k.Date, k.ID = c.Date, c.ID
+	})
+}
+
+func (s *Store) ListConcertsByIDs(ctx context.Context, ids []int) ([]models.Concert, error) {
+	if len(ids) == 0 {
+		return []models.Concert{}, nil
+	}
 
 	const q = `
 	SELECT ` + concertCols + `
 	FROM concerts
 	WHERE deleted_at IS NULL
-	  AND (
-	    name ILIKE $2
-	    OR similarity(name, $1) >= $4
+	  AND id = ANY($1::int[])`
+
+	rows, err := s.pool.Query(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanConcerts(rows, true)
+}
+
+// ListConcertsByVenue pages through a venue's concert history, most recent first.
+// afterDate/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListConcertsByVenue(ctx context.Context, venueID int, afterDate *time.Time, afterID int, limit int) ([]models.Concert, error) {
+	const q = `
+	SELECT ` + concertCols + `
+	FROM concerts
+	WHERE venue_id = $1 AND deleted_at IS NULL
+	  AND ($2::timestamp IS NULL OR (date, id) < ($2, $3))
+	ORDER BY date DESC, id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, venueID, afterDate, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanConcerts(rows, true)
+}
+
+// ListConcertsByArtist pages through concerts the artist has an act in.
+// Upcoming pages run oldest-first from now; past pages run newest-first.
+// afterDate/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListConcertsByArtist(ctx context.Context, artistID int, upcoming bool, afterDate *time.Time, afterID int, limit int) ([]models.Concert, error) {
+	qualifiedCols, err := qualifyColumns("c", concertCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build concert columns: %w", err)
+	}
+
+	// direction-dependent fragments are constants, never user input
+	dateFilter, keyset, order := `c.date < NOW()`, `(c.date, c.id) < ($2, $3)`, `c.date DESC, c.id DESC`
+	if upcoming {
+		dateFilter, keyset, order = `c.date >= NOW()`, `(c.date, c.id) > ($2, $3)`, `c.date ASC, c.id ASC`
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `
+	FROM concerts c
+	WHERE c.deleted_at IS NULL
+	  AND EXISTS (
+	    SELECT 1 FROM acts a
+	    WHERE a.concert_id = c.id AND a.artist_id = $1 AND a.deleted_at IS NULL
 	  )
-	ORDER BY
-	  (lower(name) = lower($1)) DESC,
-	  (name ILIKE $1 || '%') DESC,
-	  similarity(name, $1) DESC,
-	  date DESC,
-	  id ASC
-	LIMIT $3`
+	  AND ` + dateFilter + `
+	  AND ($2::timestamp IS NULL OR ` + keyset + `)
+	ORDER BY ` + order + `
+	LIMIT $4`
 
-	rows, err := s.pool.Query(ctx, q, query, likeQuery, maxResults, s.searchTrgmSimilarityThreshold)
+	rows, err := s.pool.Query(ctx, q, artistID, afterDate, afterID, limit)
 	if err != nil {
 		return nil, err
 	}
 
 	return scanConcerts(rows, true)
 }
+
+// ListCalendarConcerts returns concerts dated in [from, to), oldest first, listing at
+// most perDay concerts per calendar day; DayConcertCount holds each day's full count.
+//
+// attendedBy limits to concerts the user attended; followedBy limits
+// to concerts with an act by an artist the user follows. Nil means no restriction.
+func (s *Store) ListCalendarConcerts(ctx context.Context, from, to time.Time, attendedBy, followedBy *int, perDay int) ([]models.CalendarConcert, error) {
+	q := `
+	WITH month AS (
+		SELECT ` + concertCols + `,
+		  row_number() OVER (PARTITION BY date::date ORDER BY date ASC, id ASC) AS day_rank,
+		  count(*) OVER (PARTITION BY date::date) AS day_count
+		FROM concerts c
+		WHERE deleted_at IS NULL
+		  AND date >= $1 AND date < $2
+		  AND (
+		    $3::int IS NULL
+		    OR EXISTS (
+		      SELECT 1 FROM concert_attendance ca
+		      WHERE ca.user_id = $3 AND ca.concert_id = c.id
+		    )
+		  )
+		  AND (
+		    $4::int IS NULL
+		    OR EXISTS (
+		      SELECT 1 FROM acts a
+		      JOIN artist_follows f ON f.artist_id = a.artist_id
+		      WHERE a.concert_id = c.id AND a.deleted_at IS NULL AND f.user_id = $4
+		    )
+		  )
+	)
+	SELECT ` + concertCols + `, day_count
+	FROM month
+	WHERE day_rank <= $5
+	ORDER BY date ASC, id ASC`
+
+	rows, err := s.pool.Query(ctx, q, from, to, attendedBy, followedBy, perDay)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	concerts := make([]models.CalendarConcert, 0)
+	for rows.Next() {
+		var cc models.CalendarConcert
+		if err := rows.Scan(append(concertFields(&cc.Concert), &cc.DayConcertCount)...); err != nil {
+			continue
+		}
+		concerts = append(concerts, cc)
+	}
+	return concerts, rows.Err()
+}
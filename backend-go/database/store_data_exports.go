@@ -0,0 +1,199 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const dataExportCols = `
+	id,
+	user_id,
+	status,
+	s3_key,
+	size_bytes,
+	started_at,
+	completed_at,
+	expires_at,
+	created_at
+`
+
+// dataExportFields returns scan destinations for dataExportCols, in column order.
+func dataExportFields(e *models.DataExport) []any {
+	return []any{
+		&e.ID,
+		&e.UserID,
+		&e.Status,
+		&e.S3Key,
+		&e.SizeBytes,
+		&e.StartedAt,
+		&e.CompletedAt,
+		&e.ExpiresAt,
+		&e.CreatedAt,
+	}
+}
+
+func scanDataExports(rows pgx.Rows) ([]*models.DataExport, error) {
+	defer rows.Close()
+	exports := make([]*models.DataExport, 0)
+	for rows.Next() {
+		var e models.DataExport
+		if err := rows.Scan(dataExportFields(&e)...); err != nil {
+			continue
+		}
+		exports = append(exports, &e)
+	}
+	return exports, rows.Err()
+}
+
+// CreateDataExport queues an export for a user. Returns ErrDuplicate while the user
+// already has one queued or processing.
+func (s *Store) CreateDataExport(ctx context.Context, userID int) (*models.DataExport, error) {
+	const q = `
+	INSERT INTO data_exports (user_id)
+	VALUES ($1)
+	ON CONFLICT DO NOTHING
+	RETURNING ` + dataExportCols
+
+	var e models.DataExport
+	err := s.pool.QueryRow(ctx, q, userID).Scan(dataExportFields(&e)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrDuplicate
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// GetDataExport returns one of a user's exports.
+func (s *Store) GetDataExport(ctx context.Context, userID int, exportID int) (*models.DataExport, error) {
+	const q = `
+	SELECT ` + dataExportCols + `
+	FROM data_exports
+	WHERE id = $1 AND user_id = $2`
+
+	var e models.DataExport
+	err := s.pool.QueryRow(ctx, q, exportID, userID).Scan(dataExportFields(&e)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ListDataExports returns a user's most recent exports, newest first.
+func (s *Store) ListDataExports(ctx context.Context, userID int, limit int) ([]*models.DataExport, error) {
+	const q = `
+	SELECT ` + dataExportCols + `
+	FROM data_exports
+	WHERE user_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT $2`
+
+	rows, err := s.pool.Query(ctx, q, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataExports(rows)
+}
+
+// ClaimQueuedDataExports marks up to limit queued exports as processing and returns them.
+func (s *Store) ClaimQueuedDataExports(ctx context.Context, limit int) ([]*models.DataExport, error) {
+	const q = `
+	UPDATE data_exports SET status = $1, started_at = NOW()
+	WHERE id IN (
+		SELECT id FROM data_exports
+		WHERE status = $2
+		ORDER BY created_at, id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + dataExportCols
+
+	rows, err := s.pool.Query(ctx, q, models.DataExportStatusProcessing, models.DataExportStatusQueued, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataExports(rows)
+}
+
+// SetDataExportCompleted records the uploaded archive and when it expires.
+func (s *Store) SetDataExportCompleted(ctx context.Context, exportID int, s3Key string, sizeBytes int64, expiresAt time.Time) error {
+	const q = `
+	UPDATE data_exports
+	SET status = $1, s3_key = $2, size_bytes = $3, completed_at = NOW(), expires_at = $4
+	WHERE id = $5`
+
+	_, err := s.pool.Exec(ctx, q, models.DataExportStatusCompleted, s3Key, sizeBytes, expiresAt, exportID)
+	return err
+}
+
+func (s *Store) SetDataExportFailed(ctx context.Context, exportID int) error {
+	const q = `UPDATE data_exports SET status = $1 WHERE id = $2`
+	_, err := s.pool.Exec(ctx, q, models.DataExportStatusFailed, exportID)
+	return err
+}
+
+// ResetStuckDataExports requeues exports that have been processing for longer than stuckAfter.
+func (s *Store) ResetStuckDataExports(ctx context.Context, stuckAfter time.Duration) error {
+	const q = `
+	UPDATE data_exports SET status = $1, started_at = NULL
+	WHERE status = $2 AND started_at < $3`
+	cutoff := time.Now().Add(-stuckAfter)
+	_, err := s.pool.Exec(ctx, q, models.DataExportStatusQueued, models.DataExportStatusProcessing, cutoff)
+	return err
+}
+
+// ListExpiredDataExports returns up to limit completed exports whose archive has expired.
+func (s *Store) ListExpiredDataExports(ctx context.Context, limit int) ([]*models.DataExport, error) {
+	const q = `
+	SELECT ` + dataExportCols + `
+	FROM data_exports
+	WHERE status = $1 AND expires_at < NOW()
+	ORDER BY expires_at
+	LIMIT $2`
+
+	rows, err := s.pool.Query(ctx, q, models.DataExportStatusCompleted, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataExports(rows)
+}
+
+// SetDataExportExpired marks an export whose archive has been deleted from storage.
+func (s *Store) SetDataExportExpired(ctx context.Context, exportID int) error {
+	const q = `UPDATE data_exports SET status = $1 WHERE id = $2`
+	_, err := s.pool.Exec(ctx, q, models.DataExportStatusExpired, exportID)
+	return err
+}
+
+// ListDataExportKeys returns the storage keys of every archive built for a user that
+// hasn't been deleted yet.
+func (s *Store) ListDataExportKeys(ctx context.Context, userID int) ([]string, error) {
+	const q = `
+	SELECT s3_key FROM data_exports
+	WHERE user_id = $1 AND s3_key IS NOT NULL AND status <> $2`
+
+	rows, err := s.pool.Query(ctx, q, userID, models.DataExportStatusExpired)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// ListFeedEntries pages through a user's home feed, newest activity first. Candidates are
+//   - public, uploaded videos from users they follow
+//   - public, uploaded videos by others at concerts they attended
+//   - concerts added for artists they follow
+//
+// created at or after since. Candidates at the same concert collapse into one entry,
+// keeping up to previewSize video IDs; videos without a concert are an entry each.
+// after is the previous page's last entry (nil for the first page). Later pages only
+// aggregate candidates up to after's activity time; concerts with newer activity were
+// already shown and are skipped.
+func (s *Store) ListFeedEntries(ctx context.Context, userID int, since time.Time, after *models.FeedEntry, previewSize int, limit int) ([]models.FeedEntry, error) {
+	const q = `
+	WITH candidates AS (
+		SELECT v.id AS video_id, c.id AS concert_id, v.created_at AS activity_at, $7::text AS reason
+		FROM user_follows f
+		JOIN users u ON u.id = f.followee_id AND u.deleted_at IS NULL
+		JOIN videos v ON v.user_id = f.followee_id
+		LEFT JOIN concerts c ON v.event_type = $5 AND c.id = v.event_id AND c.deleted_at IS NULL
+		WHERE f.follower_id = $1
+		  AND v.deleted_at IS NULL
+		  AND v.status = $3
+		  AND v.visibility = $4
+		  AND v.created_at >= $2
+		  AND ($11::timestamp IS NULL OR v.created_at <= $11)
+
+		UNION ALL
+
+		SELECT v.id, c.id, v.created_at, $8::text
+		FROM concert_attendance ca
+		JOIN concerts c ON c.id = ca.concert_id AND c.deleted_at IS NULL
+		JOIN videos v ON v.event_type = $5 AND v.event_id = ca.concert_id
+		JOIN users u ON u.id = v.user_id AND u.deleted_at IS NULL
+		WHERE ca.user_id = $1
+		  AND v.user_id <> $1
+		  AND v.deleted_at IS NULL
+		  AND v.status = $3
+		  AND v.visibility = $4
+		  AND v.created_at >= $2
+		  AND ($11::timestamp IS NULL OR v.created_at <= $11)
+
+		UNION ALL
+
+		SELECT DISTINCT NULL::int, c.id, c.created_at, $9::text
+		FROM artist_follows af
+		JOIN artists ar ON ar.id = af.artist_id AND ar.deleted_at IS NULL
+		JOIN acts a ON a.artist_id = af.artist_id AND a.deleted_at IS NULL
+		JOIN concerts c ON c.id = a.concert_id AND c.deleted_at IS NULL
+		WHERE af.user_id = $1
+		  AND c.created_at >= $2
+		  AND ($11::timestamp IS NULL OR c.created_at <= $11)
+	),
+	-- concerts with activity newer than the cursor: their entries were on an earlier page
+	shown AS (
+		SELECT v.event_id AS concert_id
+		FROM videos v
+		JOIN users u ON u.id = v.user_id AND u.deleted_at IS NULL
+		WHERE $11::timestamp IS NOT NULL
+		  AND v.event_type = $5
+		  AND v.deleted_at IS NULL
+		  AND v.status = $3
+		  AND v.visibility = $4
+		  AND v.created_at > $11
+		  AND (
+		    EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $1 AND f.followee_id = v.user_id)
+		    OR (v.user_id <> $1 AND EXISTS (SELECT 1 FROM concert_attendance ca WHERE ca.user_id = $1 AND ca.concert_id = v.event_id))
+		  )
+
+		UNION
+
+		SELECT c.id
+		FROM artist_follows af
+		JOIN artists ar ON ar.id = af.artist_id AND ar.deleted_at IS NULL
+		JOIN acts a ON a.artist_id = af.artist_id AND a.deleted_at IS NULL
+		JOIN concerts c ON c.id = a.concert_id AND c.deleted_at IS NULL
+		WHERE $11::timestamp IS NOT NULL
+		  AND af.user_id = $1
+		  AND c.created_at > $11
+	),
+	entries AS (
+		SELECT
+		  concert_id,
+		  CASE WHEN concert_id IS NULL THEN video_id END AS loose_video_id,
+		  MAX(activity_at) AS activity_at,
+		  array_agg(DISTINCT reason) AS reasons,
+		  COUNT(DISTINCT video_id) AS video_count,
+		  (array_agg(DISTINCT video_id ORDER BY video_id DESC) FILTER (WHERE video_id IS NOT NULL))[1:$10::int] AS video_ids
+		FROM candidates
+		WHERE concert_id IS NULL OR concert_id NOT IN (SELECT concert_id FROM shown WHERE concert_id IS NOT NULL)
+		GROUP BY concert_id, CASE WHEN concert_id IS NULL THEN video_id END
+	)
+	SELECT concert_id, loose_video_id, activity_at, reasons, video_count, video_ids
+	FROM entries
+	WHERE $11::timestamp IS NULL
+	   OR (activity_at, COALESCE(concert_id, 0), COALESCE(loose_video_id, 0)) < ($11, $12::int, $13::int)
+	ORDER BY activity_at DESC, COALESCE(concert_id, 0) DESC, COALESCE(loose_video_id, 0) DESC
+	LIMIT $6`
+
+	var afterActivityAt *time.Time
+	var afterConcertID, afterVideoID int
+	if after != nil {
+		afterActivityAt = &after.ActivityAt
+		if after.ConcertID != nil {
+			afterConcertID = *after.ConcertID
+		}
+		if after.VideoID != nil {
+			afterVideoID = *after.VideoID
+		}
+	}
+
+	rows, err := s.pool.Query(ctx, q,
+		userID,
+		since,
+		models.VideoStatusCompleted,
+		models.VideoVisibilityPublic,
+		models.EventTypeConcert,
+		limit,
+		models.FeedReasonFollowedUser,
+		models.FeedReasonAttended,
+		models.FeedReasonFollowedArtist,
+		previewSize,
+		afterActivityAt,
+		afterConcertID,
+		afterVideoID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.FeedEntry, 0)
+	for rows.Next() {
+		var e models.FeedEntry
+		if err := rows.Scan(&e.ConcertID, &e.VideoID, &e.ActivityAt, &e.Reasons, &e.VideoCount, &e.VideoIDs); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
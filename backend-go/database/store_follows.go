@@ -0,0 +1,239 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// FollowUser makes followerID follow followeeID and reports whether the follow is new.
+// Following twice is not an error.
+func (s *Store) FollowUser(ctx context.Context, followerID int, followeeID int) (bool, error) {
+	const q = `
+	INSERT INTO user_follows (follower_id, followee_id)
+	VALUES ($1, $2)
+	ON CONFLICT (follower_id, followee_id) DO NOTHING`
+
+	tag, err := s.pool.Exec(ctx, q, followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// UnfollowUser removes a user follow. Removing a missing follow is not an error.
+func (s *Store) UnfollowUser(ctx context.Context, followerID int, followeeID int) error {
+	const q = `
+	DELETE FROM user_follows
+	WHERE follower_id = $1 AND followee_id = $2`
+
+	_, err := s.pool.Exec(ctx, q, followerID, followeeID)
+	return err
+}
+
+// FollowArtist makes userID follow artistID. Following twice is not an error.
+func (s *Store) FollowArtist(ctx context.Context, userID int, artistID int) error {
+	const q = `
+	INSERT INTO artist_follows (user_id, artist_id)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id, artist_id) DO NOTHING`
+
+	_, err := s.pool.Exec(ctx, q, userID, artistID)
+	return err
+}
+
+// UnfollowArtist removes an artist follow. Removing a missing follow is not an error.
+func (s *Store) UnfollowArtist(ctx context.Context, userID int, artistID int) error {
+	const q = `
+	DELETE FROM artist_follows
+	WHERE user_id = $1 AND artist_id = $2`
+
+	_, err := s.pool.Exec(ctx, q, userID, artistID)
+	return err
+}
+
+// ListFollowedUserIDs returns which of userIDs followerID follows.
+func (s *Store) ListFollowedUserIDs(ctx context.Context, followerID int, userIDs []int) ([]int, error) {
+	if len(userIDs) == 0 {
+		return []int{}, nil
+	}
+
+	const q = `
+	SELECT followee_id
+	FROM user_follows
+	WHERE follower_id = $1 AND followee_id = ANY($2::int[])`
+
+	rows, err := s.pool.Query(ctx, q, followerID, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// ListFollowedArtistIDs returns which of artistIDs userID follows.
+func (s *Store) ListFollowedArtistIDs(ctx context.Context, userID int, artistIDs []int) ([]int, error) {
+	if len(artistIDs) == 0 {
+		return []int{}, nil
+	}
+
+	const q = `
+	SELECT artist_id
+	FROM artist_follows
+	WHERE user_id = $1 AND artist_id = ANY($2::int[])`
+
+	rows, err := s.pool.Query(ctx, q, userID, artistIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanIDs(rows)
+}
+
+// CountArtistFollowers returns how many active users follow an artist.
+func (s *Store) CountArtistFollowers(ctx context.Context, artistID int) (int, error) {
+	const q = `
+	SELECT COUNT(*)
+	FROM artist_follows f
+	JOIN users u ON u.id = f.user_id AND u.deleted_at IS NULL
+	WHERE f.artist_id = $1`
+
+	var count int
+	if err := s.pool.QueryRow(ctx, q, artistID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListUserFollowers pages through the users following userID, most recent follow first.
+// afterFollowedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListUserFollowers(ctx context.Context, userID int, afterFollowedAt *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `, f.created_at
+	FROM user_follows f
+	JOIN users u ON u.id = f.follower_id AND u.deleted_at IS NULL
+	WHERE f.followee_id = $1
+	  AND ($2::timestamp IS NULL OR (f.created_at, f.follower_id) < ($2, $3))
+	ORDER BY f.created_at DESC, f.follower_id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, userID, afterFollowedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFollowedUsers(rows)
+}
+
+// ListFollowingUsers pages through the users userID follows, most recent follow first.
+// afterFollowedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListFollowingUsers(ctx context.Context, userID int, afterFollowedAt *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `, f.created_at
+	FROM user_follows f
+	JOIN users u ON u.id = f.followee_id AND u.deleted_at IS NULL
+	WHERE f.follower_id = $1
+	  AND ($2::timestamp IS NULL OR (f.created_at, f.followee_id) < ($2, $3))
+	ORDER BY f.created_at DESC, f.followee_id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, userID, afterFollowedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFollowedUsers(rows)
+}
+
+// ListArtistFollowers pages through the users following artistID, most recent follow first.
+// afterFollowedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListArtistFollowers(ctx context.Context, artistID int, afterFollowedAt *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `, f.created_at
+	FROM artist_follows f
+	JOIN users u ON u.id = f.user_id AND u.deleted_at IS NULL
+	WHERE f.artist_id = $1
+	  AND ($2::timestamp IS NULL OR (f.created_at, f.user_id) < ($2, $3))
+	ORDER BY f.created_at DESC, f.user_id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, artistID, afterFollowedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanFollowedUsers(rows)
+}
+
+// ListFollowingArtists pages through the artists userID follows, most recent follow first.
+// afterFollowedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListFollowingArtists(ctx context.Context, userID int, afterFollowedAt *time.Time, afterID int, limit int) ([]models.FollowedArtist, error) {
+	qualifiedCols, err := qualifyColumns("a", artistCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build artist columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `, f.created_at
+	FROM artist_follows f
+	JOIN artists a ON a.id = f.artist_id AND a.deleted_at IS NULL
+	WHERE f.user_id = $1
+	  AND ($2::timestamp IS NULL OR (f.created_at, f.artist_id) < ($2, $3))
+	ORDER BY f.created_at DESC, f.artist_id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, userID, afterFollowedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	artists := make([]models.FollowedArtist, 0)
+	for rows.Next() {
+		var a models.FollowedArtist
+		if err := rows.Scan(append(artistFields(&a.Artist), &a.FollowedAt)...); err != nil {
+			continue
+		}
+		artists = append(artists, a)
+	}
+	return artists, rows.Err()
+}
+
+func scanFollowedUsers(rows pgx.Rows) ([]models.FollowedUser, error) {
+	defer rows.Close()
+	users := make([]models.FollowedUser, 0)
+	for rows.Next() {
+		var u models.FollowedUser
+		if err := rows.Scan(append(userFields(&u.User), &u.FollowedAt)...); err != nil {
+			continue
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func scanIDs(rows pgx.Rows) ([]int, error) {
+	defer rows.Close()
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,267 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const identityCols = `
+	subject,
+	user_id,
+	email,
+	email_verified,
+	created_at,
+	last_seen_at
+`
+
+// identityFields returns scan destinations for identityCols, in column order.
+func identityFields(i *models.UserIdentity) []any {
+	return []any{
+		&i.Subject,
+		&i.UserID,
+		&i.Email,
+		&i.EmailVerified,
+		&i.CreatedAt,
+		&i.LastSeenAt,
+	}
+}
+
+// GetUserIdentity returns the identity with the given Auth0 subject, whatever the
+// state of the account it belongs to.
+func (s *Store) GetUserIdentity(ctx context.Context, subject string) (*models.UserIdentity, error) {
+	const q = `
+	SELECT ` + identityCols + `
+	FROM user_identities
+	WHERE subject = $1`
+
+	var i models.UserIdentity
+	err := s.pool.QueryRow(ctx, q, subject).Scan(identityFields(&i)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// ListUserIdentities returns a user's identities, oldest first.
+func (s *Store) ListUserIdentities(ctx context.Context, userID int) ([]models.UserIdentity, error) {
+	const q = `
+	SELECT ` + identityCols + `
+	FROM user_identities
+	WHERE user_id = $1
+	ORDER BY created_at, subject`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := make([]models.UserIdentity, 0)
+	for rows.Next() {
+		var i models.UserIdentity
+		if err := rows.Scan(identityFields(&i)...); err != nil {
+			continue
+		}
+		identities = append(identities, i)
+	}
+	return identities, rows.Err()
+}
+
+// AddUserIdentity links a new identity to a user. Returns ErrDuplicate when the
+// subject is already linked to an account.
+func (s *Store) AddUserIdentity(ctx context.Context, identity *models.UserIdentity) error {
+	const q = `
+	INSERT INTO user_identities (subject, user_id, email, email_verified)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (subject) DO NOTHING`
+
+	tag, err := s.pool.Exec(ctx, q, identity.Subject, identity.UserID, identity.Email, identity.EmailVerified)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrDuplicate
+	}
+	return nil
+}
+
+// TouchUserIdentity records a sign-in with the identity and what the provider
+// currently reports about its email.
+func (s *Store) TouchUserIdentity(ctx context.Context, subject string, email *string, emailVerified bool) error {
+	const q = `
+	UPDATE user_identities
+	SET email = COALESCE($2, email), email_verified = $3, last_seen_at = NOW()
+	WHERE subject = $1`
+
+	_, err := s.pool.Exec(ctx, q, subject, email, emailVerified)
+	return err
+}
+
+// DeleteUserIdentity unlinks one of a user's identities.
+func (s *Store) DeleteUserIdentity(ctx context.Context, userID int, subject string) error {
+	const q = `DELETE FROM user_identities WHERE user_id = $1 AND subject = $2`
+
+	tag, err := s.pool.Exec(ctx, q, userID, subject)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// FindUserByVerifiedEmail returns the active user one of whose identities has a
+// verified email equal to email (ignoring case). Returns ErrNotFound unless exactly
+// one account matches.
+func (s *Store) FindUserByVerifiedEmail(ctx context.Context, email string) (*models.User, error) {
+	qualifiedCols, err := qualifyColumns("u", userCols)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+	SELECT DISTINCT ` + qualifiedCols + `
+	FROM user_identities i
+	JOIN users u ON u.id = i.user_id AND u.deleted_at IS NULL
+	WHERE i.email_verified AND LOWER(i.email) = LOWER($1)
+	LIMIT 2`
+
+	rows, err := s.pool.Query(ctx, q, email)
+	if err != nil {
+		return nil, err
+	}
+	users, err := scanUsers(rows, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, apperr.ErrNotFound
+	}
+	return &users[0], nil
+}
+
+// ShareVerifiedEmail reports whether two users each have an identity with the same verified email.
+func (s *Store) ShareVerifiedEmail(ctx context.Context, userID int, otherUserID int) (bool, error) {
+	const q = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM user_identities a
+		JOIN user_identities b ON LOWER(b.email) = LOWER(a.email)
+		WHERE a.user_id = $1 AND a.email_verified
+		  AND b.user_id = $2 AND b.email_verified
+	)`
+
+	var shared bool
+	err := s.pool.QueryRow(ctx, q, userID, otherUserID).Scan(&shared)
+	return shared, err
+}
+
+// CreateAccountLinkCode stores the hash of a one-time code that links another identity to userID.
+func (s *Store) CreateAccountLinkCode(ctx context.Context, userID int, codeHash string, expiresAt time.Time) error {
+	const q = `
+	INSERT INTO account_link_codes (code_hash, user_id, expires_at)
+	VALUES ($1, $2, $3)`
+
+	_, err := s.pool.Exec(ctx, q, codeHash, userID, expiresAt)
+	return err
+}
+
+// ConsumeAccountLinkCode deletes an unexpired link code and returns the user who issued it.
+// Returns ErrNotFound for unknown, used or expired codes.
+func (s *Store) ConsumeAccountLinkCode(ctx context.Context, codeHash string) (int, error) {
+	const q = `
+	DELETE FROM account_link_codes
+	WHERE code_hash = $1 AND expires_at > NOW()
+	RETURNING user_id`
+
+	var userID int
+	err := s.pool.QueryRow(ctx, q, codeHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, apperr.ErrNotFound
+	}
+	return userID, err
+}
+
+// MergeUsers folds sourceID's account into targetID: identities, videos, comments and
+// catalog contributions move over, as do reactions, attendance, follows, blocks and
+// reports that don't collide with the target's own; personal access tokens are deleted.
+// The source is soft-deleted with merged_into_user_id set, so whatever collided is
+// purged with it later.
+func (s *Store) MergeUsers(ctx context.Context, sourceID int, targetID int) error {
+	const q = `
+	WITH
+	identities AS (UPDATE user_identities SET user_id = $2 WHERE user_id = $1),
+	link_codes AS (DELETE FROM account_link_codes WHERE user_id = $1),
+	tokens AS (DELETE FROM personal_access_tokens WHERE user_id = $1),
+	videos AS (UPDATE videos SET user_id = $2, updated_at = NOW() WHERE user_id = $1),
+	comments AS (UPDATE comments SET user_id = $2 WHERE user_id = $1),
+	mentions AS (
+		UPDATE comment_mentions m SET user_id = $2
+		WHERE m.user_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM comment_mentions t WHERE t.user_id = $2 AND t.comment_id = m.comment_id)
+	),
+	reactions AS (
+		UPDATE video_reactions r SET user_id = $2
+		WHERE r.user_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM video_reactions t WHERE t.user_id = $2 AND t.video_id = r.video_id)
+	),
+	attendance AS (
+		UPDATE concert_attendance a SET user_id = $2
+		WHERE a.user_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM concert_attendance t WHERE t.user_id = $2 AND t.concert_id = a.concert_id)
+	),
+	artist_follows AS (
+		UPDATE artist_follows f SET user_id = $2
+		WHERE f.user_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM artist_follows t WHERE t.user_id = $2 AND t.artist_id = f.artist_id)
+	),
+	following AS (
+		UPDATE user_follows f SET follower_id = $2
+		WHERE f.follower_id = $1 AND f.followee_id <> $2
+		  AND NOT EXISTS (SELECT 1 FROM user_follows t WHERE t.follower_id = $2 AND t.followee_id = f.followee_id)
+	),
+	followers AS (
+		UPDATE user_follows f SET followee_id = $2
+		WHERE f.followee_id = $1 AND f.follower_id <> $2
+		  AND NOT EXISTS (SELECT 1 FROM user_follows t WHERE t.followee_id = $2 AND t.follower_id = f.follower_id)
+	),
+	blocking AS (
+		UPDATE user_blocks b SET blocker_id = $2
+		WHERE b.blocker_id = $1 AND b.blocked_id <> $2
+		  AND NOT EXISTS (SELECT 1 FROM user_blocks t WHERE t.blocker_id = $2 AND t.blocked_id = b.blocked_id)
+	),
+	blocked_by AS (
+		UPDATE user_blocks b SET blocked_id = $2
+		WHERE b.blocked_id = $1 AND b.blocker_id <> $2
+		  AND NOT EXISTS (SELECT 1 FROM user_blocks t WHERE t.blocked_id = $2 AND t.blocker_id = b.blocker_id)
+	),
+	reports AS (
+		UPDATE comment_reports r SET reporter_id = $2
+		WHERE r.reporter_id = $1
+		  AND NOT EXISTS (SELECT 1 FROM comment_reports t WHERE t.reporter_id = $2 AND t.comment_id = r.comment_id)
+	),
+	inbox AS (UPDATE notifications SET user_id = $2 WHERE user_id = $1),
+	artists AS (UPDATE artists SET created_by_user_id = $2 WHERE created_by_user_id = $1),
+	songs AS (UPDATE songs SET created_by_user_id = $2 WHERE created_by_user_id = $1)
+	UPDATE users
+	SET deleted_at = NOW(), merged_into_user_id = $2, updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL`
+
+	tag, err := s.pool.Exec(ctx, q, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const notificationCols = `
+	id,
+	user_id,
+	type,
+	actor_id,
+	video_id,
+	concert_id,
+	comment_id,
+	data,
+	read_at,
+	created_at
+`
+
+// notificationFields returns scan destinations for notificationCols, in column order.
+func notificationFields(n *models.Notification) []any {
+	return []any{
+		&n.ID,
+		&n.UserID,
+		&n.Type,
+		&n.ActorID,
+		&n.VideoID,
+		&n.ConcertID,
+		&n.CommentID,
+		&n.Data,
+		&n.ReadAt,
+		&n.CreatedAt,
+	}
+}
+
+func scanNotifications(rows pgx.Rows, allowPartial bool) ([]models.Notification, error) {
+	defer rows.Close()
+	notifications := make([]models.Notification, 0)
+	for rows.Next() {
+		var n models.Notification
+		if err := rows.Scan(notificationFields(&n)...); err != nil {
+			if allowPartial {
+				continue
+			}
+			return notifications, err
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// CreateNotification inserts n unless its recipient has turned off n.Type
+// (or no longer exists). It reports whether a row was written.
+func (s *Store) CreateNotification(ctx context.Context, n *models.Notification) (bool, error) {
+	const q = `
+	INSERT INTO notifications (user_id, type, actor_id, video_id, concert_id, comment_id, data)
+	SELECT u.id, $2, $3, $4, $5, $6, COALESCE($7::jsonb, '{}')
+	FROM users u
+	WHERE u.id = $1
+	  AND u.deleted_at IS NULL
+	  AND COALESCE((u.notification_preferences ->> $2)::boolean, true)`
+
+	tag, err := s.pool.Exec(ctx, q, n.UserID, n.Type, n.ActorID, n.VideoID, n.ConcertID, n.CommentID, n.Data)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ListNotifications pages through userID's notifications, newest first.
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListNotifications(ctx context.Context, userID int, unreadOnly bool, afterCreatedAt *time.Time, afterID int, limit int) ([]models.Notification, error) {
+	const q = `
+	SELECT ` + notificationCols + `
+	FROM notifications
+	WHERE user_id = $1
+	  AND (NOT $2 OR read_at IS NULL)
+	  AND ($3::timestamp IS NULL OR (created_at, id) < ($3, $4))
+	ORDER BY created_at DESC, id DESC
+	LIMIT $5`
+
+	rows, err := s.pool.Query(ctx, q, userID, unreadOnly, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanNotifications(rows, true)
+}
+
+// CountUnreadNotifications returns how many of userID's notifications are unread.
+func (s *Store) CountUnreadNotifications(ctx context.Context, userID int) (int, error) {
+	const q = `
+	SELECT COUNT(*)
+	FROM notifications
+	WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	err := s.pool.QueryRow(ctx, q, userID).Scan(&count)
+	return count, err
+}
+
+// MarkNotificationRead marks one of userID's notifications read.
+// It reports whether the notification exists for that user; marking twice is fine.
+func (s *Store) MarkNotificationRead(ctx context.Context, userID int, notificationID int) (bool, error) {
+	const q = `
+	UPDATE notifications
+	SET read_at = COALESCE(read_at, NOW())
+	WHERE id = $1 AND user_id = $2`
+
+	tag, err := s.pool.Exec(ctx, q, notificationID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// MarkAllNotificationsRead marks every unread notification of userID created at or
+// before `before` as read, so entries that arrive mid-request stay unread.
+func (s *Store) MarkAllNotificationsRead(ctx context.Context, userID int, before time.Time) error {
+	const q = `
+	UPDATE notifications
+	SET read_at = NOW()
+	WHERE user_id = $1 AND read_at IS NULL AND created_at <= $2`
+
+	_, err := s.pool.Exec(ctx, q, userID, before)
+	return err
+}
+
+// GetNotificationPreferences returns userID's per-type opt-outs ({"type": false}).
+func (s *Store) GetNotificationPreferences(ctx context.Context, userID int) (map[string]bool, error) {
+	const q = `
+	SELECT notification_preferences
+	FROM users
+	WHERE id = $1 AND deleted_at IS NULL`
+
+	var prefs map[string]bool
+	if err := s.pool.QueryRow(ctx, q, userID).Scan(&prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences merges changes into userID's preferences and returns the result.
+func (s *Store) UpdateNotificationPreferences(ctx context.Context, userID int, changes map[string]bool) (map[string]bool, error) {
+	const q = `
+	UPDATE users
+	SET notification_preferences = notification_preferences || $2::jsonb, updated_at = NOW()
+	WHERE id = $1 AND deleted_at IS NULL
+	RETURNING notification_preferences`
+
+	var prefs map[string]bool
+	if err := s.pool.QueryRow(ctx, q, userID, changes).Scan(&prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
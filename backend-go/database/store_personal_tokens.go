@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const personalTokenCols = `
+	id,
+	user_id,
+	name,
+	token_hash,
+	token_prefix,
+	scopes,
+	expires_at,
+	last_used_at,
+	revoked_at,
+	created_at
+`
+
+// personalTokenFields returns scan destinations for personalTokenCols, in column order.
+func personalTokenFields(t *models.PersonalAccessToken) []any {
+	return []any{
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.TokenHash,
+		&t.TokenPrefix,
+		&t.Scopes,
+		&t.ExpiresAt,
+		&t.LastUsedAt,
+		&t.RevokedAt,
+		&t.CreatedAt,
+	}
+}
+
+// CreatePersonalAccessToken stores a new token for t.UserID from its hash, prefix, name, scopes and expiry.
+func (s *Store) CreatePersonalAccessToken(ctx context.Context, t *models.PersonalAccessToken) (*models.PersonalAccessToken, error) {
+	const q = `
+	INSERT INTO personal_access_tokens (user_id, name, token_hash, token_prefix, scopes, expires_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING ` + personalTokenCols
+
+	var created models.PersonalAccessToken
+	err := s.pool.QueryRow(ctx, q, t.UserID, t.Name, t.TokenHash, t.TokenPrefix, t.Scopes, t.ExpiresAt).Scan(personalTokenFields(&created)...)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ListPersonalAccessTokens returns a user's unrevoked tokens, expired ones included, newest first.
+func (s *Store) ListPersonalAccessTokens(ctx context.Context, userID int) ([]*models.PersonalAccessToken, error) {
+	const q = `
+	SELECT ` + personalTokenCols + `
+	FROM personal_access_tokens
+	WHERE user_id = $1 AND revoked_at IS NULL
+	ORDER BY created_at DESC, id DESC`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]*models.PersonalAccessToken, 0)
+	for rows.Next() {
+		var t models.PersonalAccessToken
+		if err := rows.Scan(personalTokenFields(&t)...); err != nil {
+			continue
+		}
+		tokens = append(tokens, &t)
+	}
+	return tokens, rows.Err()
+}
+
+// RevokePersonalAccessToken revokes one of a user's tokens. Returns ErrNotFound for
+// unknown or already revoked tokens.
+func (s *Store) RevokePersonalAccessToken(ctx context.Context, userID int, tokenID int) error {
+	const q = `
+	UPDATE personal_access_tokens
+	SET revoked_at = NOW()
+	WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	tag, err := s.pool.Exec(ctx, q, tokenID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// UsePersonalAccessToken looks up a live token (unrevoked, unexpired, owned by an active
+// user) by hash and records that it was used. Also returns an Auth0 subject linked to the
+// owner, so the caller can be resolved like a signed-in user. Returns ErrNotFound otherwise.
+func (s *Store) UsePersonalAccessToken(ctx context.Context, tokenHash string) (*models.PersonalAccessToken, string, error) {
+	qualifiedCols, err := qualifyColumns("t", personalTokenCols)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to build token columns: %w", err)
+	}
+
+	q := `
+	UPDATE personal_access_tokens t
+	SET last_used_at = NOW()
+	FROM users u
+	WHERE t.token_hash = $1
+	  AND t.revoked_at IS NULL
+	  AND t.expires_at > NOW()
+	  AND u.id = t.user_id AND u.deleted_at IS NULL
+	RETURNING ` + qualifiedCols + `,
+		(SELECT i.subject FROM user_identities i WHERE i.user_id = u.id ORDER BY i.created_at, i.subject LIMIT 1)`
+
+	var t models.PersonalAccessToken
+	var subject *string
+	err = s.pool.QueryRow(ctx, q, tokenHash).Scan(append(personalTokenFields(&t), &subject)...)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && subject == nil) {
+		return nil, "", apperr.ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &t, *subject, nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TakeRateLimitToken spends one token from key's bucket if it has one. The bucket holds
+// burst tokens and gains one every interval; it is stored as the time it will be full
+// again. Reports whether a token was spent and, either way, how long until the bucket is full.
+func (s *Store) TakeRateLimitToken(ctx context.Context, key string, interval time.Duration, burst int) (bool, time.Duration, error) {
+	const q = `
+	INSERT INTO rate_limit_buckets AS b (key, full_at)
+	VALUES ($1, NOW() + make_interval(secs => $2))
+	ON CONFLICT (key) DO UPDATE
+	SET full_at = GREATEST(b.full_at, NOW()) + make_interval(secs => $2)
+	WHERE GREATEST(b.full_at, NOW()) <= NOW() + make_interval(secs => $3)
+	RETURNING EXTRACT(EPOCH FROM b.full_at - NOW())::float8`
+
+	// a token is available while the bucket is less than (burst-1) intervals from full
+	slack := time.Duration(burst-1) * interval
+
+	var fullIn float64
+	err := s.pool.QueryRow(ctx, q, key, interval.Seconds(), slack.Seconds()).Scan(&fullIn)
+	if err == nil {
+		return true, secondsToDuration(fullIn), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, 0, err
+	}
+
+	// the update was skipped: the bucket is empty
+	const denied = `
+	SELECT EXTRACT(EPOCH FROM full_at - NOW())::float8
+	FROM rate_limit_buckets
+	WHERE key = $1`
+
+	err = s.pool.QueryRow(ctx, denied, key).Scan(&fullIn)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// deleted in between, having refilled: the caller can retry at once
+		return false, 0, nil
+	}
+	if err != nil {
+		return false, 0, err
+	}
+	return false, secondsToDuration(fullIn), nil
+}
+
+// DeleteFullRateLimitBuckets removes buckets that have refilled; a missing bucket counts as full.
+func (s *Store) DeleteFullRateLimitBuckets(ctx context.Context) error {
+	const q = `DELETE FROM rate_limit_buckets WHERE full_at <= NOW()`
+
+	_, err := s.pool.Exec(ctx, q)
+	return err
+}
+
+func secondsToDuration(secs float64) time.Duration {
+	return time.Duration(secs * float64(time.Second))
+}
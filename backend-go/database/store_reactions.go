@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// UpsertReaction sets userID's reaction to videoID, replacing any earlier one.
+func (s *Store) UpsertReaction(ctx context.Context, videoID int, userID int, reaction string) (*models.Reaction, error) {
+	const q = `
+	INSERT INTO video_reactions (video_id, user_id, reaction)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (video_id, user_id)
+	DO UPDATE SET reaction = EXCLUDED.reaction, updated_at = NOW()
+	RETURNING video_id, user_id, reaction, created_at, updated_at`
+
+	var r models.Reaction
+	err := s.pool.QueryRow(ctx, q, videoID, userID, reaction).Scan(
+		&r.VideoID,
+		&r.UserID,
+		&r.Reaction,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// DeleteReaction removes userID's reaction to videoID. Removing a missing reaction is a no-op.
+func (s *Store) DeleteReaction(ctx context.Context, videoID int, userID int) error {
+	const q = `
+	DELETE FROM video_reactions
+	WHERE video_id = $1 AND user_id = $2`
+
+	_, err := s.pool.Exec(ctx, q, videoID, userID)
+	return err
+}
+
+// ListReactionCounts totals reactions per type for each of videoIDs.
+// Videos with no reactions are absent from the result.
+func (s *Store) ListReactionCounts(ctx context.Context, videoIDs []int) (map[int]map[string]int, error) {
+	counts := make(map[int]map[string]int)
+	if len(videoIDs) == 0 {
+		return counts, nil
+	}
+
+	const q = `
+	SELECT video_id, reaction, COUNT(*)
+	FROM video_reactions
+	WHERE video_id = ANY($1::int[])
+	GROUP BY video_id, reaction`
+
+	rows, err := s.pool.Query(ctx, q, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var videoID, n int
+		var reaction string
+		if err := rows.Scan(&videoID, &reaction, &n); err != nil {
+			continue
+		}
+		if counts[videoID] == nil {
+			counts[videoID] = make(map[string]int)
+		}
+		counts[videoID][reaction] = n
+	}
+	return counts, rows.Err()
+}
+
+// ListUserReactions returns userID's reaction to each of videoIDs they reacted to.
+func (s *Store) ListUserReactions(ctx context.Context, userID int, videoIDs []int) (map[int]string, error) {
+	reactions := make(map[int]string)
+	if len(videoIDs) == 0 {
+		return reactions, nil
+	}
+
+	const q = `
+	SELECT video_id, reaction
+	FROM video_reactions
+	WHERE user_id = $1
+	  AND video_id = ANY($2::int[])`
+
+	rows, err := s.pool.Query(ctx, q, userID, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var videoID int
+		var reaction string
+		if err := rows.Scan(&videoID, &reaction); err != nil {
+			continue
+		}
+		reactions[videoID] = reaction
+	}
+	return reactions, rows.Err()
+}
+
+// AddVideoViews adds a batch of buffered view counts (video ID -> new views) in one statement.
+// Videos deleted since the views were recorded are skipped.
+func (s *Store) AddVideoViews(ctx context.Context, views map[int]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(views))
+	counts := make([]int64, 0, len(views))
+	for id, n := range views {
+		ids = append(ids, id)
+		counts = append(counts, n)
+	}
+
+	const q = `
+	UPDATE videos v
+	SET view_count = v.view_count + d.n
+	FROM unnest($1::int[], $2::bigint[]) AS d(id, n)
+	WHERE v.id = d.id`
+
+	_, err := s.pool.Exec(ctx, q, ids, counts)
+	return err
+}
+
+// ListReactionsByUser returns every reaction a user has left, oldest first.
+func (s *Store) ListReactionsByUser(ctx context.Context, userID int) ([]models.Reaction, error) {
+	const q = `
+	SELECT video_id, user_id, reaction, created_at, updated_at
+	FROM video_reactions
+	WHERE user_id = $1
+	ORDER BY created_at, video_id`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reactions := make([]models.Reaction, 0)
+	for rows.Next() {
+		var r models.Reaction
+		if err := rows.Scan(&r.VideoID, &r.UserID, &r.Reaction, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			continue
+		}
+		reactions = append(reactions, r)
+	}
+	return reactions, rows.Err()
+}
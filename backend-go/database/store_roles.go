@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const roleChangeCols = `
+	id,
+	user_id,
+	old_role,
+	new_role,
+	changed_by,
+	source,
+	reason,
+	created_at
+`
+
+// roleChangeFields returns scan destinations for roleChangeCols, in column order.
+func roleChangeFields(r *models.RoleChange) []any {
+	return []any{
+		&r.ID,
+		&r.UserID,
+		&r.OldRole,
+		&r.NewRole,
+		&r.ChangedBy,
+		&r.Source,
+		&r.Reason,
+		&r.CreatedAt,
+	}
+}
+
+// SetUserRole changes a user's role and records the change in the audit trail, in one statement.
+// changedBy is nil for the bootstrap CLI. Setting the role a user already has records nothing
+// and returns a nil change.
+func (s *Store) SetUserRole(ctx context.Context, userID int, role string, changedBy *int, source string, reason *string) (*models.RoleChange, error) {
+	const q = `
+	WITH target AS (
+		SELECT id, role AS old_role
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE
+	), updated AS (
+		UPDATE users u
+		SET role = $2, updated_at = NOW()
+		FROM target t
+		WHERE u.id = t.id AND t.old_role <> $2
+		RETURNING u.id, t.old_role
+	)
+	INSERT INTO role_changes (user_id, old_role, new_role, changed_by, source, reason)
+	SELECT id, old_role, $2, $3, $4, $5
+	FROM updated
+	RETURNING ` + roleChangeCols
+
+	var r models.RoleChange
+	err := s.pool.QueryRow(ctx, q, userID, role, changedBy, source, reason).Scan(roleChangeFields(&r)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// nothing changed: either the user is gone or already has the role
+		if _, err := s.GetUserByID(ctx, userID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListRoleChanges pages through a user's role changes, newest first.
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListRoleChanges(ctx context.Context, userID int, afterCreatedAt *time.Time, afterID int, limit int) ([]models.RoleChange, error) {
+	const q = `
+	SELECT ` + roleChangeCols + `
+	FROM role_changes
+	WHERE user_id = $1
+	  AND ($2::timestamp IS NULL OR (created_at, id) < ($2, $3))
+	ORDER BY created_at DESC, id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, userID, afterCreatedAt, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]models.RoleChange, 0)
+	for rows.Next() {
+		var r models.RoleChange
+		if err := rows.Scan(roleChangeFields(&r)...); err != nil {
+			continue
+		}
+		changes = append(changes, r)
+	}
+	return changes, rows.Err()
+}
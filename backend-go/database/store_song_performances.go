@@ -2,8 +2,11 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/jackc/pgx/v5"
 )
@@ -18,9 +21,9 @@ const songPerformanceCols = `
 	deleted_at
 `
 
-func scanSongPerformance(row pgx.Row) (*models.SongPerformance, error) {
-	var sp models.SongPerformance
-	if err := row.Scan(
+// songPerformanceFields returns scan destinations for songPerformanceCols, in column order.
+func songPerformanceFields(sp *models.SongPerformance) []any {
+	return []any{
 		&sp.ID,
 		&sp.ActID,
 		&sp.SongID,
@@ -28,7 +31,12 @@ func scanSongPerformance(row pgx.Row) (*models.SongPerformance, error) {
 		&sp.StartedAt,
 		&sp.CreatedAt,
 		&sp.DeletedAt,
-	); err != nil {
+	}
+}
+
+func scanSongPerformance(row pgx.Row) (*models.SongPerformance, error) {
+	var sp models.SongPerformance
+	if err := row.Scan(songPerformanceFields(&sp)...); err != nil {
 		return nil, err
 	}
 	return &sp, nil
@@ -72,3 +80,82 @@ func (s *Store) ListSongPerformancesByConcert(ctx context.Context, concertID int
 
 	return scanSongPerformances(rows, true)
 }
+
+// ListPlaysBySong pages through every concert a song was performed at, most recent first.
+// afterDate/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListPlaysBySong(ctx context.Context, songID int, afterDate *time.Time, afterID int, limit int) ([]models.SongPlay, error) {
+	spCols, err := qualifyColumns("sp", songPerformanceCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build song performance columns: %w", err)
+	}
+	cCols, err := qualifyColumns("c", concertCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build concert columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + spCols + `,
+	  a.artist_id,` + cCols + `
+	FROM song_performances sp
+	INNER JOIN acts a ON a.id = sp.act_id AND a.deleted_at IS NULL
+	INNER JOIN concerts c ON c.id = a.concert_id AND c.deleted_at IS NULL
+	WHERE sp.song_id = $1
+	  AND sp.deleted_at IS NULL
+	  AND ($2::timestamp IS NULL OR (c.date, sp.id) < ($2, $3))
+	ORDER BY c.date DESC, sp.id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, songID, afterDate, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plays := make([]models.SongPlay, 0)
+	for rows.Next() {
+		var p models.SongPlay
+		dest := append(songPerformanceFields(&p.SongPerformance), &p.PerformerArtistID)
+		if err := rows.Scan(append(dest, concertFields(&p.Concert)...)...); err != nil {
+			continue
+		}
+		plays = append(plays, p)
+	}
+	return plays, rows.Err()
+}
+
+// GetSongPerformanceStats reads a song's row from the song_performance_stats view.
+// Returns apperr.ErrNotFound if the song had no performances as of the last refresh.
+func (s *Store) GetSongPerformanceStats(ctx context.Context, songID int) (*models.SongPerformanceStats, error) {
+	const q = `
+	SELECT song_id, total_plays, first_played_at, last_played_at, avg_position,
+	       opener_count, closer_count, video_count, computed_at
+	FROM song_performance_stats
+	WHERE song_id = $1`
+
+	var st models.SongPerformanceStats
+	err := s.pool.QueryRow(ctx, q, songID).Scan(
+		&st.SongID,
+		&st.TotalPlays,
+		&st.FirstPlayedAt,
+		&st.LastPlayedAt,
+		&st.AvgPosition,
+		&st.OpenerCount,
+		&st.CloserCount,
+		&st.VideoCount,
+		&st.ComputedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// RefreshSongPerformanceStats rebuilds the song_performance_stats view.
+// CONCURRENTLY keeps the view readable during the refresh (needs the unique song_id index).
+func (s *Store) RefreshSongPerformanceStats(ctx context.Context) error {
+	_, err := s.pool.Exec(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY song_performance_stats`)
+	return err
+}
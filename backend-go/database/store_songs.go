@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
@@ -23,9 +24,9 @@ const songCols = `
 	deleted_at
 `
 
-func scanSong(row pgx.Row) (*models.Song, error) {
-	var s models.Song
-	err := row.Scan(
+// songFields returns scan destinations for songCols, in column order.
+func songFields(s *models.Song) []any {
+	return []any{
 		&s.ID,
 		&s.Title,
 		&s.ArtistID,
@@ -37,7 +38,12 @@ func scanSong(row pgx.Row) (*models.Song, error) {
 		&s.CreatedByUserID,
 		&s.CreatedAt,
 		&s.DeletedAt,
-	)
+	}
+}
+
+func scanSong(row pgx.Row) (*models.Song, error) {
+	var s models.Song
+	err := row.Scan(songFields(&s)...)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, apperr.ErrNotFound
 	}
@@ -72,26 +78,108 @@ func (s *Store) GetSongByID(ctx context.Context, id int) (*models.Song, error) {
 	return scanSong(s.pool.QueryRow(ctx, q, id))
 }
 
-func (s *Store) SearchSongs(ctx context.Context, query string, maxResults int) ([]models.Song, error) {
+func (s *Store) SongExists(ctx context.Context, songID int) (bool, error) {
+	const q = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM songs
+		WHERE id = $1 AND deleted_at IS NULL
+	)`
+
+	var exists bool
+	if err := s.pool.QueryRow(ctx, q, songID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// SearchSongs returns one page of songs matching query, with each row's ranking position.
+func (s *Store) SearchSongs(ctx context.Context, query string, after *SearchKeyset, maxResults int) (*SearchPage[models.Song], error) {
 	query, likeQuery := prepareSearchQuery(query)
+	afterExact, k := keysetStart(after)
 
-	const q = `
-	SELECT ` + songCols + `
-	FROM songs
-	WHERE deleted_at IS NULL
-	  AND (title ILIKE $2 OR similarity(title, $1) >= $4)
+	q := `
+	WITH ranked AS (
+		SELECT ` + songCols + `,
+		  (lower(title) = lower($1))::int AS rank_exact,
+		  (title ILIKE $1 || '%')::int AS rank_prefix,
+		  similarity(title, $1) AS rank_similarity
+		FROM songs
+		WHERE deleted_at IS NULL
+		  AND (title ILIKE $2 OR similarity(title, $1) >= $4)
+	)
+	SELECT ` + songCols + `, rank_exact, rank_prefix, rank_similarity
+	FROM ranked
+	WHERE $5::int IS NULL
+	   OR (rank_exact, rank_prefix, rank_similarity, is_verified) < ($5, $6::int, $7::real, $8::boolean)
+	   OR (
+	     (rank_exact, rank_prefix, rank_similarity, is_verified) = ($5, $6::int, $7::real, $8::boolean)
+	     AND (title, id) > ($9::text, $10::int)
+	   )
 	ORDER BY
-	  (lower(title) = lower($1)) DESC,
-	  (title ILIKE $1 || '%') DESC,
-	  similarity(title, $1) DESC,
+	  rank_exact DESC,
+	  rank_prefix DESC,
+	  rank_similarity DESC,
 	  is_verified DESC,
-	  title ASC
+	  title ASC,
+	  id ASC
 	LIMIT $3`
 
-	rows, err := s.pool.Query(ctx, q, query, likeQuery, maxResults, s.searchTrgmSimilarityThreshold)
+	rows, err := s.pool.Query(ctx, q,
+		query, likeQuery, maxResults+1, s.searchTrgmSimilarityThreshold,
+		afterExact, k.Prefix, k.Similarity, k.Verified, k.Label, k.ID,
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	return scanSongs(rows, true)
+	return scanRanked(rows, maxResults, songFields, func(song *models.Song, k *SearchKeyset) {
+		k.Verified, k.Label, k.ID = song.IsVerified, song.Title, song.ID
+	})
+}
+
+// ListSongsByArtist pages through songs the artist has performed live or is credited with,
+// most-performed first. Songs credited to the artist but never played have TimesPerformed = 0.
+// afterCount/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListSongsByArtist(ctx context.Context, artistID int, afterCount *int, afterID int, limit int) ([]models.PerformedSong, error) {
+	qualifiedCols, err := qualifyColumns("s", songCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build song columns: %w", err)
+	}
+
+	q := `
+	WITH performed AS (
+		SELECT sp.song_id, COUNT(*)::int AS times_performed
+		FROM song_performances sp
+		INNER JOIN acts a ON a.id = sp.act_id
+		WHERE a.artist_id = $1
+		  AND a.deleted_at IS NULL
+		  AND sp.deleted_at IS NULL
+		GROUP BY sp.song_id
+	)
+	SELECT ` + qualifiedCols + `,
+	  COALESCE(p.times_performed, 0) AS times_performed
+	FROM songs s
+	LEFT JOIN performed p ON p.song_id = s.id
+	WHERE s.deleted_at IS NULL
+	  AND (s.artist_id = $1 OR p.song_id IS NOT NULL)
+	  AND ($2::int IS NULL OR (COALESCE(p.times_performed, 0), s.id) < ($2, $3))
+	ORDER BY times_performed DESC, s.id DESC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, artistID, afterCount, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	songs := make([]models.PerformedSong, 0)
+	for rows.Next() {
+		var ps models.PerformedSong
+		if err := rows.Scan(append(songFields(&ps.Song), &ps.TimesPerformed)...); err != nil {
+			continue
+		}
+		songs = append(songs, ps)
+	}
+	return songs, rows.Err()
 }
@@ -3,7 +3,9 @@ package database
 import (
 	"context"
 	"errors"
+	"strings"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/jackc/pgx/v5"
 )
@@ -16,14 +18,17 @@ const userCols = `
 	display_name,
 	profile_picture,
 	bio,
+	location_privacy,
+	role,
+	username_set_at,
 	created_at,
 	updated_at,
 	deleted_at
 `
 
-func scanUser(row pgx.Row) (*models.User, error) {
-	var u models.User
-	err := row.Scan(
+// userFields returns scan destinations for userCols, in column order.
+func userFields(u *models.User) []any {
+	return []any{
 		&u.ID,
 		&u.Auth0ID,
 		&u.Email,
@@ -31,10 +36,18 @@ func scanUser(row pgx.Row) (*models.User, error) {
 		&u.DisplayName,
 		&u.ProfilePictureURL,
 		&u.Bio,
+		&u.LocationPrivacy,
+		&u.Role,
+		&u.UsernameSetAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 		&u.DeletedAt,
-	)
+	}
+}
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	var u models.User
+	err := row.Scan(userFields(&u)...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,11 +76,15 @@ func (s *Store) GetUserByID(ctx context.Context, userID int) (*models.User, erro
 	FROM users
 	WHERE id = $1 AND deleted_at IS NULL`
 
-	return scanUser(s.pool.QueryRow(ctx, q, userID))
+	u, err := scanUser(s.pool.QueryRow(ctx, q, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return u, err
 }
 
 // UpdateUserProfile updates display_name, profile_picture, and bio for a user.
-// Unlike UpsertUser, this uses direct assignment — null values explicitly clear the field.
+// Unlike CreateUser, this uses direct assignment — null values explicitly clear the field.
 func (s *Store) UpdateUserProfile(ctx context.Context, userID int, displayName string, profilePicture *string, bio *string) (*models.User, error) {
 	const q = `
 	UPDATE users
@@ -78,77 +95,334 @@ func (s *Store) UpdateUserProfile(ctx context.Context, userID int, displayName s
 	return scanUser(s.pool.QueryRow(ctx, q, displayName, profilePicture, bio, userID))
 }
 
+// SetUserLocationPrivacy sets how a user's video coordinates are shown to other viewers.
+func (s *Store) SetUserLocationPrivacy(ctx context.Context, userID int, locationPrivacy string) (*models.User, error) {
+	const q = `
+	UPDATE users
+	SET location_privacy = $1, updated_at = NOW()
+	WHERE id = $2 AND deleted_at IS NULL
+	RETURNING ` + userCols
+
+	u, err := scanUser(s.pool.QueryRow(ctx, q, locationPrivacy, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return u, err
+}
+
+// ListUserLocationPrivacy returns the location_privacy setting of each of userIDs, keyed by user ID.
+func (s *Store) ListUserLocationPrivacy(ctx context.Context, userIDs []int) (map[int]string, error) {
+	settings := make(map[int]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+
+	const q = `
+	SELECT id, location_privacy
+	FROM users
+	WHERE id = ANY($1::int[])`
+
+	rows, err := s.pool.Query(ctx, q, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var setting string
+		if err := rows.Scan(&id, &setting); err != nil {
+			return settings, err
+		}
+		settings[id] = setting
+	}
+	return settings, rows.Err()
+}
+
+// GetUserByUsername looks up an active user by their exact username.
+func (s *Store) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	const q = `
+	SELECT ` + userCols + `
+	FROM users
+	WHERE LOWER(username) = LOWER($1) AND deleted_at IS NULL`
+
+	u, err := scanUser(s.pool.QueryRow(ctx, q, username))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return u, err
+}
+
+// GetUserProfileCounts totals a user's videos, attended concerts, distinct artists seen and follows.
+// Unless includePrivate is set (the user viewing their own profile), only public,
+// uploaded videos and public attendance are counted.
+func (s *Store) GetUserProfileCounts(ctx context.Context, userID int, includePrivate bool) (*models.UserProfileCounts, error) {
+	const q = `
+	SELECT
+	  (SELECT COUNT(*) FROM videos v
+	   WHERE v.user_id = $1
+	     AND v.deleted_at IS NULL
+	     AND ($2 OR (v.visibility = $3 AND v.status = $4))),
+	  (SELECT COUNT(*) FROM concert_attendance ca
+	   JOIN concerts c ON c.id = ca.concert_id AND c.deleted_at IS NULL
+	   WHERE ca.user_id = $1
+	     AND ($2 OR ca.visibility = $5)),
+	  (SELECT COUNT(DISTINCT a.artist_id) FROM concert_attendance ca
+	   JOIN concerts c ON c.id = ca.concert_id AND c.deleted_at IS NULL
+	   JOIN acts a ON a.concert_id = ca.concert_id AND a.deleted_at IS NULL
+	   WHERE ca.user_id = $1
+	     AND ($2 OR ca.visibility = $5)),
+	  (SELECT COUNT(*) FROM user_follows f
+	   JOIN users u ON u.id = f.follower_id AND u.deleted_at IS NULL
+	   WHERE f.followee_id = $1),
+	  (SELECT COUNT(*) FROM user_follows f
+	   JOIN users u ON u.id = f.followee_id AND u.deleted_at IS NULL
+	   WHERE f.follower_id = $1),
+	  (SELECT COUNT(*) FROM artist_follows f
+	   JOIN artists a ON a.id = f.artist_id AND a.deleted_at IS NULL
+	   WHERE f.user_id = $1)`
+
+	var counts models.UserProfileCounts
+	err := s.pool.QueryRow(ctx, q,
+		userID,
+		includePrivate,
+		models.VideoVisibilityPublic,
+		models.VideoStatusCompleted,
+		models.AttendanceVisibilityPublic,
+	).Scan(
+		&counts.Videos,
+		&counts.ConcertsAttended,
+		&counts.ArtistsSeen,
+		&counts.Followers,
+		&counts.Following,
+		&counts.FollowingArtists,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// GetUserByAuth0ID returns the active user that any of their linked identities' subject belongs to.
 func (s *Store) GetUserByAuth0ID(ctx context.Context, auth0ID string) (*models.User, error) {
 	const q = `
 	SELECT ` + userCols + `
 	FROM users
-	WHERE auth0_id = $1 AND deleted_at IS NULL`
+	WHERE id = (SELECT user_id FROM user_identities WHERE subject = $1)
+	  AND deleted_at IS NULL`
 
 	return scanUser(s.pool.QueryRow(ctx, q, auth0ID))
 }
 
-func (s *Store) UpsertUser(ctx context.Context, user *models.User) (*models.User, error) {
-	if user == nil {
-		return nil, errors.New("user is nil")
+// GetUserByEmail returns the active user with the given email, ignoring case.
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	const q = `
+	SELECT ` + userCols + `
+	FROM users
+	WHERE LOWER(email) = LOWER($1) AND deleted_at IS NULL`
+
+	u, err := scanUser(s.pool.QueryRow(ctx, q, email))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return u, err
+}
+
+// CreateUser inserts a user together with the identity they signed up with. The subject
+// only goes into user_identities; users.auth0_id is left NULL (see migration 000035).
+// Returns ErrDuplicate when the email or username is already taken.
+func (s *Store) CreateUser(ctx context.Context, user *models.User, identity *models.UserIdentity) (*models.User, error) {
+	if user == nil || identity == nil {
+		return nil, errors.New("user or identity is nil")
 	}
-	if user.Auth0ID == "" {
-		return nil, errors.New("missing auth0_id")
+	if identity.Subject == "" {
+		return nil, errors.New("missing identity subject")
 	}
 	if user.Email == "" || user.Username == "" || user.DisplayName == "" {
 		return nil, errors.New("missing required fields: email/username/displayName")
 	}
 
 	const q = `
-	INSERT INTO users (auth0_id, email, username, display_name, profile_picture, bio)
-	VALUES ($1, $2, $3, $4, $5, $6)
-	ON CONFLICT (auth0_id)
-	DO UPDATE SET
-		email = EXCLUDED.email,
-		username = EXCLUDED.username,
-		display_name = EXCLUDED.display_name,
-		profile_picture = COALESCE(users.profile_picture, EXCLUDED.profile_picture),
-		bio = COALESCE(users.bio, EXCLUDED.bio),
-		updated_at = NOW()
-	RETURNING ` + userCols
+	WITH created AS (
+		INSERT INTO users (email, username, display_name, profile_picture, bio)
+		VALUES ($2, $3, $4, $5, $6)
+		ON CONFLICT DO NOTHING
+		RETURNING ` + userCols + `
+	),
+	identity AS (
+		INSERT INTO user_identities (subject, user_id, email, email_verified)
+		SELECT $1, id, $7, $8 FROM created
+	)
+	SELECT ` + userCols + ` FROM created`
 
-	return scanUser(s.pool.QueryRow(
+	u, err := scanUser(s.pool.QueryRow(
 		ctx,
 		q,
-		user.Auth0ID,
+		identity.Subject,
 		user.Email,
 		user.Username,
 		user.DisplayName,
 		user.ProfilePictureURL,
 		user.Bio,
+		identity.Email,
+		identity.EmailVerified,
 	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrDuplicate
+	}
+	return u, err
+}
+
+// SyncUserFromProvider refreshes a user's email, and their username until they have
+// picked one in the app, from what their identity provider reports. Either is left
+// alone when another account already uses it; pass an empty username to keep it.
+func (s *Store) SyncUserFromProvider(ctx context.Context, userID int, email string, username string) (*models.User, error) {
+	const q = `
+	UPDATE users u SET
+		email = CASE
+			WHEN $2 <> '' AND NOT EXISTS (SELECT 1 FROM users o WHERE LOWER(o.email) = LOWER($2) AND o.id <> u.id)
+			THEN $2 ELSE u.email END,
+		username = CASE
+			WHEN u.username_set_at IS NULL AND $3 <> ''
+			 AND NOT EXISTS (SELECT 1 FROM users o WHERE LOWER(o.username) = LOWER($3) AND o.id <> u.id)
+			THEN $3 ELSE u.username END,
+		updated_at = NOW()
+	WHERE u.id = $1 AND u.deleted_at IS NULL
+	RETURNING ` + userCols
+
+	u, err := scanUser(s.pool.QueryRow(ctx, q, userID, email, username))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrNotFound
+	}
+	return u, err
 }
 
-func (s *Store) SearchUsers(ctx context.Context, query string, maxResults int) ([]models.User, error) {
+// SetUsername sets the username the user picked in the app. Sync no longer changes it afterwards.
+// Returns ErrDuplicate when another account has it, ignoring case.
+func (s *Store) SetUsername(ctx context.Context, userID int, username string) (*models.User, error) {
+	const q = `
+	UPDATE users u
+	SET username = $1, username_set_at = NOW(), updated_at = NOW()
+	WHERE u.id = $2 AND u.deleted_at IS NULL
+	  AND NOT EXISTS (SELECT 1 FROM users o WHERE LOWER(o.username) = LOWER($1) AND o.id <> u.id)
+	RETURNING ` + userCols
+
+	u, err := scanUser(s.pool.QueryRow(ctx, q, username, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperr.ErrDuplicate
+	}
+	return u, err
+}
+
+// UsernameTaken reports whether any account, deleted ones included, holds username (ignoring case).
+func (s *Store) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	const q = `SELECT EXISTS (SELECT 1 FROM users WHERE LOWER(username) = LOWER($1))`
+
+	var taken bool
+	err := s.pool.QueryRow(ctx, q, username).Scan(&taken)
+	return taken, err
+}
+
+// SearchUsers returns one page of users matching query on username or display name,
+// with each row's ranking position.
+// Username matches outrank display-name matches: rank_exact/rank_prefix are 2 for
+// username, 1 for display name, 0 for neither.
+func (s *Store) SearchUsers(ctx context.Context, query string, after *SearchKeyset, maxResults int) (*SearchPage[models.User], error) {
 	query, likeQuery := prepareSearchQuery(query)
+	afterExact, k := keysetStart(after)
+
+	q := `
+	WITH ranked AS (
+		SELECT ` + userCols + `,
+		  CASE
+		    WHEN lower(username) = lower($1) THEN 2
+		    WHEN lower(display_name) = lower($1) THEN 1
+		    ELSE 0
+		  END AS rank_exact,
+		  CASE
+		    WHEN username ILIKE $1 || '%' THEN 2
+		    WHEN display_name ILIKE $1 || '%' THEN 1
+		    ELSE 0
+		  END AS rank_prefix,
+		  GREATEST(similarity(username, $1), similarity(display_name, $1)) AS rank_similarity
+		FROM users
+		WHERE deleted_at IS NULL
+		  AND (
+		    username ILIKE $2
+		    OR display_name ILIKE $2
+		    OR similarity(username, $1) >= $4
+		    OR similarity(display_name, $1) >= $4
+		  )
+	)
+	SELECT ` + userCols + `, rank_exact, rank_prefix, rank_similarity
+	FROM ranked
+	WHERE $5::int IS NULL
+	   OR (rank_exact, rank_prefix, rank_similarity) < ($5, $6::int, $7::real)
+	   OR (
+	     (rank_exact, rank_prefix, rank_similarity) = ($5, $6::int, $7::real)
+	     AND (username, id) > ($8::text, $9::int)
+	   )
+	ORDER BY
+	  rank_exact DESC,
+	  rank_prefix DESC,
+	  rank_similarity DESC,
+	  username ASC,
+	  id ASC
+	LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q,
+		query, likeQuery, maxResults+1, s.searchTrgmSimilarityThreshold,
+		afterExact, k.Prefix, k.Similarity, k.Label, k.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRanked(rows, maxResults, userFields, func(u *models.User, k *SearchKeyset) {
+		k.Label, k.ID = u.Username, u.ID
+	})
+}
+
+// ListUsersByUsernames returns the active users among usernames, ignoring case like
+// GetUserByUsername.
+func (s *Store) ListUsersByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	if len(usernames) == 0 {
+		return []models.User{}, nil
+	}
+
+	lowered := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		lowered = append(lowered, strings.ToLower(username))
+	}
 
 	const q = `
 	SELECT ` + userCols + `
 	FROM users
 	WHERE deleted_at IS NULL
-	  AND (
-	    username ILIKE $2
-	    OR display_name ILIKE $2
-	    OR similarity(username, $1) >= $4
-	    OR similarity(display_name, $1) >= $4
-	  )
-	ORDER BY
-	  (lower(username) = lower($1)) DESC,
-	  (lower(display_name) = lower($1)) DESC,
-	  (username ILIKE $1 || '%') DESC,
-	  (display_name ILIKE $1 || '%') DESC,
-	  GREATEST(similarity(username, $1), similarity(display_name, $1)) DESC,
-	  username ASC
-	LIMIT $3`
+	  AND LOWER(username) = ANY($1::text[])`
 
-	rows, err := s.pool.Query(ctx, q, query, likeQuery, maxResults, s.searchTrgmSimilarityThreshold)
+	rows, err := s.pool.Query(ctx, q, lowered)
 	if err != nil {
 		return nil, err
 	}
+	return scanUsers(rows, true)
+}
+
+// ListUsersByIDs returns the active users among ids.
+func (s *Store) ListUsersByIDs(ctx context.Context, ids []int) ([]models.User, error) {
+	if len(ids) == 0 {
+		return []models.User{}, nil
+	}
+
+	const q = `
+	SELECT ` + userCols + `
+	FROM users
+	WHERE deleted_at IS NULL
+	  AND id = ANY($1::int[])`
 
+	rows, err := s.pool.Query(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
 	return scanUsers(rows, true)
 }
@@ -2,11 +2,16 @@ package database
 
 import (
 	"context"
+	"errors"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/jackc/pgx/v5"
 )
 
+// venueEarthPoint must stay in sync with idx_venues_earth_point (migration 000017).
+const venueEarthPoint = `ll_to_earth(latitude, longitude)`
+
 const venueCols = `
 	id,
 	name,
@@ -21,9 +26,9 @@ const venueCols = `
 	deleted_at
 `
 
-func scanVenue(row pgx.Row) (*models.Venue, error) {
-	var v models.Venue
-	if err := row.Scan(
+// venueFields returns scan destinations for venueCols, in column order.
+func venueFields(v *models.Venue) []any {
+	return []any{
 		&v.ID,
 		&v.Name,
 		&v.Latitude,
@@ -35,7 +40,15 @@ func scanVenue(row pgx.Row) (*models.Venue, error) {
 		&v.GooglePlaceID,
 		&v.CreatedAt,
 		&v.DeletedAt,
-	); err != nil {
+	}
+}
+
+func scanVenue(row pgx.Row) (*models.Venue, error) {
+	var v models.Venue
+	if err := row.Scan(venueFields(&v)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
 		return nil, err
 	}
 	return &v, nil
@@ -75,3 +88,115 @@ func (s *Store) ListVenuesByIDs(ctx context.Context, ids []int) ([]models.Venue,
 
 	return scanVenues(rows, true)
 }
+
+func (s *Store) GetVenueByID(ctx context.Context, id int) (*models.Venue, error) {
+	const q = `
+	SELECT ` + venueCols + `
+	FROM venues
+	WHERE id = $1 AND deleted_at IS NULL`
+
+	return scanVenue(s.pool.QueryRow(ctx, q, id))
+}
+
+func (s *Store) VenueExists(ctx context.Context, venueID int) (bool, error) {
+	const q = `
+	SELECT EXISTS (
+		SELECT 1
+		FROM venues
+		WHERE id = $1 AND deleted_at IS NULL
+	)`
+
+	var exists bool
+	if err := s.pool.QueryRow(ctx, q, venueID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// SearchVenues returns one page of venues matching query on name or city,
+// with each row's ranking position.
+// An exact name match outranks an exact city match (rank_exact 2 vs 1).
+func (s *Store) SearchVenues(ctx context.Context, query string, after *SearchKeyset, maxResults int) (*SearchPage[models.Venue], error) {
+	query, likeQuery := prepareSearchQuery(query)
+	afterExact, k := keysetStart(after)
+
+	q := `
+	WITH ranked AS (
+		SELECT ` + venueCols + `,
+		  CASE
+		    WHEN lower(name) = lower($1) THEN 2
+		    WHEN lower(city) = lower($1) THEN 1
+		    ELSE 0
+		  END AS rank_exact,
+		  (name ILIKE $1 || '%')::int AS rank_prefix,
+		  GREATEST(similarity(name, $1), COALESCE(similarity(city, $1), 0)) AS rank_similarity
+		FROM venues
+		WHERE deleted_at IS NULL
+		  AND (
+		    name ILIKE $2
+		    OR city ILIKE $2
+		    OR similarity(name, $1) >= $4
+		    OR similarity(city, $1) >= $4
+		  )
+	)
+	SELECT ` + venueCols + `, rank_exact, rank_prefix, rank_similarity
+	FROM ranked
+	WHERE $5::int IS NULL
+	   OR (rank_exact, rank_prefix, rank_similarity) < ($5, $6::int, $7::real)
+	   OR (
+	     (rank_exact, rank_prefix, rank_similarity) = ($5, $6::int, $7::real)
+	     AND (name, id) > ($8::text, $9::int)
+	   )
+	ORDER BY
+	  rank_exact DESC,
+	  rank_prefix DESC,
+	  rank_similarity DESC,
+	  name ASC,
+	  id ASC
+	LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q,
+		query, likeQuery, maxResults+1, s.searchTrgmSimilarityThreshold,
+		afterExact, k.Prefix, k.Similarity, k.Label, k.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRanked(rows, maxResults, venueFields, func(v *models.Venue, k *SearchKeyset) {
+		k.Label, k.ID = v.Name, v.ID
+	})
+}
+
+// ListVenuesNearby returns venues within radiusMeters of (lat, lng), closest first.
+// earth_box is a cheap bounding-cube prefilter that hits idx_venues_earth_point;
+// earth_distance then trims the corners so the result is an exact radius.
+func (s *Store) ListVenuesNearby(ctx context.Context, lat, lng, radiusMeters float64, maxResults int) ([]models.NearbyVenue, error) {
+	q := `
+	SELECT ` + venueCols + `,
+	  earth_distance(` + venueEarthPoint + `, ll_to_earth($1, $2)) AS distance_meters
+	FROM venues
+	WHERE deleted_at IS NULL
+	  AND latitude IS NOT NULL
+	  AND longitude IS NOT NULL
+	  AND earth_box(ll_to_earth($1, $2), $3) @> ` + venueEarthPoint + `
+	  AND earth_distance(` + venueEarthPoint + `, ll_to_earth($1, $2)) <= $3
+	ORDER BY distance_meters ASC, id ASC
+	LIMIT $4`
+
+	rows, err := s.pool.Query(ctx, q, lat, lng, radiusMeters, maxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	venues := make([]models.NearbyVenue, 0)
+	for rows.Next() {
+		var nv models.NearbyVenue
+		if err := rows.Scan(append(venueFields(&nv.Venue), &nv.DistanceMeters)...); err != nil {
+			continue
+		}
+		venues = append(venues, nv)
+	}
+	return venues, rows.Err()
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const videoEventsChannel = "video_events"
+
+// ListenVideoEvents LISTENs on the video_events channel and calls handle with each event
+// until ctx is done or the connection fails; it always returns a non-nil error.
+// The connection is taken out of the pool for good, so a LISTEN never leaks back into it.
+func (s *Store) ListenVideoEvents(ctx context.Context, handle func(models.VideoEvent)) error {
+	pooled, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+videoEventsChannel); err != nil {
+		return err
+	}
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var e models.VideoEvent
+		if err := json.Unmarshal([]byte(n.Payload), &e); err != nil {
+			continue
+		}
+		handle(e)
+	}
+}
@@ -2,9 +2,11 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/jackc/pgx/v5"
 )
@@ -16,8 +18,11 @@ const videoCols = `
 	s3_key,
 	video_url,
 	thumbnail_url,
+	playback_s3_key,
 	status,
 	visibility,
+	caption,
+	share_token,
 	event_type,
 	event_id,
 	act_id,
@@ -31,6 +36,9 @@ const videoCols = `
 	thumbnail_status,
 	thumbnail_processing_started_at,
 	detection_status,
+	location_strip_status,
+	location_strip_started_at,
+	view_count,
 	created_at,
 	updated_at,
 	processed_at,
@@ -46,8 +54,11 @@ func scanVideo(row pgx.Row) (*models.Video, error) {
 		&v.S3Key,
 		&v.VideoURL,
 		&v.ThumbnailURL,
+		&v.PlaybackS3Key,
 		&v.Status,
 		&v.Visibility,
+		&v.Caption,
+		&v.ShareToken,
 		&v.EventType,
 		&v.EventID,
 		&v.ActID,
@@ -61,12 +72,18 @@ func scanVideo(row pgx.Row) (*models.Video, error) {
 		&v.ThumbnailStatus,
 		&v.ThumbnailProcessingStartedAt,
 		&v.DetectionStatus,
+		&v.LocationStripStatus,
+		&v.LocationStripStartedAt,
+		&v.ViewCount,
 		&v.CreatedAt,
 		&v.UpdatedAt,
 		&v.ProcessedAt,
 		&v.DeletedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperr.ErrNotFound
+		}
 		return nil, err
 	}
 	return &v, nil
@@ -145,23 +162,148 @@ func (s *Store) SetVideoConcert(ctx context.Context, videoID int, concertID int)
 	return err
 }
 
+// UpdateVideoSettings overwrites a video's owner-editable settings and returns the updated video.
+// A nil caption, eventType/eventID or shareToken clears that column.
+func (s *Store) UpdateVideoSettings(ctx context.Context, videoID int, visibility string, caption *string, eventType *string, eventID *int, shareToken *string) (*models.Video, error) {
+	const q = `
+	UPDATE videos
+	SET visibility = $1, caption = $2, event_type = $3, event_id = $4, share_token = $5, updated_at = NOW()
+	WHERE id = $6 AND deleted_at IS NULL
+	RETURNING ` + videoCols
+
+	return scanVideo(s.pool.QueryRow(ctx, q, visibility, caption, eventType, eventID, shareToken, videoID))
+}
+
+// SetVideoShareToken replaces a video's share token; nil revokes it.
+func (s *Store) SetVideoShareToken(ctx context.Context, videoID int, shareToken *string) error {
+	const q = `
+	UPDATE videos SET share_token = $1, updated_at = NOW()
+	WHERE id = $2 AND deleted_at IS NULL`
+
+	_, err := s.pool.Exec(ctx, q, shareToken, videoID)
+	return err
+}
+
 // SetVideoSong links a video to a song
 // TODO: add song_id column to videos table
 func (s *Store) SetVideoSong(ctx context.Context, videoID int, songID int) error {
 	return fmt.Errorf("not implemented")
 }
 
-// ListVideosByConcert returns non-deleted videos linked to the given concert.
-func (s *Store) ListVideosByConcert(ctx context.Context, concertID int) ([]*models.Video, error) {
+// ListVideosByConcert returns the videos linked to the given concert that viewerID
+// (0 when anonymous) may list: public, uploaded videos plus the viewer's own.
+func (s *Store) ListVideosByConcert(ctx context.Context, concertID int, viewerID int) ([]*models.Video, error) {
 	const q = `
 	SELECT ` + videoCols + `
 	FROM videos
 	WHERE event_type = $1
 	  AND event_id = $2
 	  AND deleted_at IS NULL
+	  AND ((visibility = $3 AND status = $4) OR user_id = $5)
 	ORDER BY recorded_at ASC NULLS LAST, created_at ASC, id ASC`
 
-	rows, err := s.pool.Query(ctx, q, models.EventTypeConcert, concertID)
+	rows, err := s.pool.Query(ctx, q, models.EventTypeConcert, concertID, models.VideoVisibilityPublic, models.VideoStatusCompleted, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, true)
+}
+
+// ListVideosByArtist pages through public, uploaded videos of an artist, newest first.
+// Matches two paths (see models.Act):
+//   - explicitly tagged: the video's act belongs to the artist
+//   - untagged fallback: the video is linked to a concert whose only act is the artist
+//
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListVideosByArtist(ctx context.Context, artistID int, afterCreatedAt *time.Time, afterID int, limit int) ([]*models.Video, error) {
+	qualifiedCols, err := qualifyColumns("v", videoCols)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build video columns: %w", err)
+	}
+
+	q := `
+	SELECT ` + qualifiedCols + `
+	FROM videos v
+	LEFT JOIN acts a ON a.id = v.act_id AND a.deleted_at IS NULL
+	WHERE v.deleted_at IS NULL
+	  AND v.status = $2
+	  AND v.visibility = $3
+	  AND (
+	    a.artist_id = $1
+	    OR (
+	      v.act_id IS NULL
+	      AND v.event_type = $4
+	      AND (SELECT COUNT(*) FROM acts ca WHERE ca.concert_id = v.event_id AND ca.deleted_at IS NULL) = 1
+	      AND EXISTS (
+	        SELECT 1 FROM acts ca
+	        WHERE ca.concert_id = v.event_id AND ca.artist_id = $1 AND ca.deleted_at IS NULL
+	      )
+	    )
+	  )
+	  AND ($5::timestamp IS NULL OR (v.created_at, v.id) < ($5, $6))
+	ORDER BY v.created_at DESC, v.id DESC
+	LIMIT $7`
+
+	rows, err := s.pool.Query(ctx, q,
+		artistID,
+		models.VideoStatusCompleted,
+		models.VideoVisibilityPublic,
+		models.EventTypeConcert,
+		afterCreatedAt,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, true)
+}
+
+// ListVideosByUser pages through a user's videos, newest first.
+// Other viewers only see public, uploaded videos; includePrivate (the owner) sees every
+// non-deleted video, including private ones and uploads still in progress.
+// afterCreatedAt/afterID is the keyset position of the previous page's last row (nil for the first page).
+func (s *Store) ListVideosByUser(ctx context.Context, userID int, includePrivate bool, afterCreatedAt *time.Time, afterID int, limit int) ([]*models.Video, error) {
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE user_id = $1
+	  AND deleted_at IS NULL
+	  AND ($2 OR (visibility = $3 AND status = $4))
+	  AND ($5::timestamp IS NULL OR (created_at, id) < ($5, $6))
+	ORDER BY created_at DESC, id DESC
+	LIMIT $7`
+
+	rows, err := s.pool.Query(ctx, q,
+		userID,
+		includePrivate,
+		models.VideoVisibilityPublic,
+		models.VideoStatusCompleted,
+		afterCreatedAt,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, true)
+}
+
+// ListPublicVideosByIDs returns the public, uploaded videos among ids, in no particular order.
+func (s *Store) ListPublicVideosByIDs(ctx context.Context, ids []int) ([]*models.Video, error) {
+	if len(ids) == 0 {
+		return []*models.Video{}, nil
+	}
+
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE id = ANY($1::int[])
+	  AND deleted_at IS NULL
+	  AND visibility = $2
+	  AND status = $3`
+
+	rows, err := s.pool.Query(ctx, q, ids, models.VideoVisibilityPublic, models.VideoStatusCompleted)
 	if err != nil {
 		return nil, err
 	}
@@ -287,3 +429,99 @@ func (s *Store) SetDetectionStatus(ctx context.Context, videoID int, status stri
 	_, err := s.pool.Exec(ctx, q, status, videoID)
 	return err
 }
+
+// QueueLocationStrip queues a video for the location-stripping remux.
+func (s *Store) QueueLocationStrip(ctx context.Context, videoID int) error {
+	const q = `
+	UPDATE videos SET location_strip_status = $1, updated_at = NOW()
+	WHERE id = $2 AND deleted_at IS NULL`
+
+	_, err := s.pool.Exec(ctx, q, models.VideoLocationStripStatusQueued, videoID)
+	return err
+}
+
+// ClaimQueuedLocationStrips atomically claims up to `limit` videos queued for the location-stripping remux.
+// FOR UPDATE SKIP LOCKED prevents double-claiming across concurrent workers/instances.
+func (s *Store) ClaimQueuedLocationStrips(ctx context.Context, limit int) ([]*models.Video, error) {
+	const q = `
+	UPDATE videos SET location_strip_status = $1, location_strip_started_at = NOW(), updated_at = NOW()
+	WHERE id IN (
+		SELECT id FROM videos
+		WHERE location_strip_status = $2 AND deleted_at IS NULL
+		ORDER BY created_at, id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING ` + videoCols
+
+	rows, err := s.pool.Query(ctx, q,
+		models.VideoLocationStripStatusProcessing,
+		models.VideoLocationStripStatusQueued,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, true)
+}
+
+// SetLocationStripCompleted points video_url at the stripped copy and marks the remux complete.
+func (s *Store) SetLocationStripCompleted(ctx context.Context, videoID int, playbackS3Key string, videoURL string) error {
+	const q = `
+	UPDATE videos
+	SET playback_s3_key = $1, video_url = $2, location_strip_status = $3, updated_at = NOW()
+	WHERE id = $4`
+
+	_, err := s.pool.Exec(ctx, q, playbackS3Key, videoURL, models.VideoLocationStripStatusCompleted, videoID)
+	return err
+}
+
+// SetLocationStripFailed marks the location-stripping remux as failed for a video.
+func (s *Store) SetLocationStripFailed(ctx context.Context, videoID int) error {
+	const q = `
+	UPDATE videos SET location_strip_status = $1, updated_at = NOW() WHERE id = $2`
+
+	_, err := s.pool.Exec(ctx, q, models.VideoLocationStripStatusFailed, videoID)
+	return err
+}
+
+// ResetStuckLocationStrips resets videos stuck in location_strip_status = processing back to queued.
+func (s *Store) ResetStuckLocationStrips(ctx context.Context, stuckAfter time.Duration) error {
+	const q = `
+	UPDATE videos SET location_strip_status = $1, location_strip_started_at = NULL, updated_at = NOW()
+	WHERE location_strip_status = $2 AND deleted_at IS NULL AND location_strip_started_at < $3`
+	cutoff := time.Now().Add(-stuckAfter)
+	_, err := s.pool.Exec(ctx, q, models.VideoLocationStripStatusQueued, models.VideoLocationStripStatusProcessing, cutoff)
+	return err
+}
+
+// ListAllUserVideos returns every video a user owns, oldest first.
+func (s *Store) ListAllUserVideos(ctx context.Context, userID int) ([]*models.Video, error) {
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE user_id = $1 AND deleted_at IS NULL
+	ORDER BY created_at, id`
+
+	rows, err := s.pool.Query(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, true)
+}
+
+// ListCompletedVideosAfter pages through uploaded, non-deleted videos by ID, for maintenance jobs.
+func (s *Store) ListCompletedVideosAfter(ctx context.Context, afterID int, limit int) ([]*models.Video, error) {
+	const q = `
+	SELECT ` + videoCols + `
+	FROM videos
+	WHERE id > $1 AND status = $2 AND deleted_at IS NULL
+	ORDER BY id
+	LIMIT $3`
+
+	rows, err := s.pool.Query(ctx, q, afterID, models.VideoStatusCompleted, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanVideos(rows, false)
+}
@@ -0,0 +1,50 @@
+package dto
+
+import "github.com/areeeeeeeb/reLive/backend-go/models"
+
+// Comment moderation actions
+const (
+	ModerationActionDismiss = "dismiss" // leave the comment up
+	ModerationActionRemove  = "remove"  // delete the comment
+)
+
+// SetRoleRequest for PUT /admin/users/:username/role.
+type SetRoleRequest struct {
+	Role   string  `json:"role" binding:"required,oneof=user moderator admin"`
+	Reason *string `json:"reason" binding:"omitempty,max=500"`
+}
+
+type RoleChangesResponse struct {
+	Results []models.RoleChange `json:"results"`
+	Meta    PageMeta            `json:"meta"`
+}
+
+// CommentReportsRequest for GET /admin/comment-reports. Status defaults to open.
+type CommentReportsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open dismissed actioned"`
+	PageRequest
+}
+
+// CommentReportItem is a report in the moderation queue with the reported comment.
+type CommentReportItem struct {
+	Report         models.CommentReport `json:"report"`
+	Comment        models.Comment       `json:"comment"`
+	CommentDeleted bool                 `json:"comment_deleted"`
+}
+
+type CommentReportsResponse struct {
+	Results []CommentReportItem `json:"results"`
+	Meta    PageMeta            `json:"meta"`
+}
+
+// ResolveCommentReportRequest for POST /admin/comment-reports/:id/resolve.
+// Resolving closes every open report of the same comment.
+type ResolveCommentReportRequest struct {
+	Action string `json:"action" binding:"required,oneof=dismiss remove"`
+}
+
+// MergeUsersRequest for POST /admin/users/:username/merge. The account in the path is
+// folded into the one named by Into.
+type MergeUsersRequest struct {
+	Into string `json:"into" binding:"required"`
+}
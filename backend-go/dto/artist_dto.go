@@ -0,0 +1,47 @@
+package dto
+
+import "github.com/areeeeeeeb/reLive/backend-go/models"
+
+const (
+	ArtistConcertsUpcoming = "upcoming"
+	ArtistConcertsPast     = "past"
+)
+
+// ArtistConcertsRequest for GET /artists/:id/concerts.
+// Without When (and without a cursor) both sections are returned; a cursor
+// always continues the section it was issued for.
+type ArtistConcertsRequest struct {
+	When string `form:"when" binding:"omitempty,oneof=upcoming past"`
+	PageRequest
+}
+
+type ConcertPage struct {
+	Results []ConcertSearchItem `json:"results"`
+	Meta    PageMeta            `json:"meta"`
+}
+
+type ArtistConcertsResponse struct {
+	Upcoming *ConcertPage `json:"upcoming,omitempty"`
+	Past     *ConcertPage `json:"past,omitempty"`
+}
+
+// ArtistSongItem is a song on an artist page.
+// IsCover is true when the song is credited to a different artist.
+type ArtistSongItem struct {
+	ID              int    `json:"id"`
+	Title           string `json:"title"`
+	DurationSeconds *int   `json:"duration_seconds,omitempty"`
+	IsVerified      bool   `json:"is_verified"`
+	IsCover         bool   `json:"is_cover"`
+	TimesPerformed  int    `json:"times_performed"`
+}
+
+type ArtistSongsResponse struct {
+	Results []ArtistSongItem `json:"results"`
+	Meta    PageMeta         `json:"meta"`
+}
+
+type ArtistVideosResponse struct {
+	Results []*models.Video `json:"results"`
+	Meta    PageMeta        `json:"meta"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// MarkAttendanceRequest for POST /concerts/:id/attendance.
+// Visibility defaults to public for new records and is left unchanged for existing ones.
+type MarkAttendanceRequest struct {
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=public private"`
+}
+
+type ConcertAttendee struct {
+	ID                int       `json:"id"`
+	Username          string    `json:"username"`
+	DisplayName       string    `json:"display_name"`
+	ProfilePictureURL *string   `json:"profile_picture,omitempty"`
+	AttendedAt        time.Time `json:"attended_at"`
+}
+
+type ConcertAttendeesResponse struct {
+	Results []ConcertAttendee `json:"results"`
+	Meta    PageMeta          `json:"meta"`
+}
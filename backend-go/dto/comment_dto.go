@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// CreateCommentRequest for POST /videos/:id/comments and /concerts/:id/comments.
+// ParentID makes it a reply; replying to a reply joins the same top-level thread.
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,max=2000"`
+	ParentID *int   `json:"parent_id"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+type ReportCommentRequest struct {
+	Reason  string  `json:"reason" binding:"required,oneof=spam harassment hate sexual other"`
+	Details *string `json:"details" binding:"omitempty,max=1000"`
+}
+
+// CommentItem is a comment as shown in a thread. A deleted comment that still has
+// replies is a placeholder: Deleted is set and Author, Body and Mentions are empty.
+type CommentItem struct {
+	ID         int           `json:"id"`
+	ParentID   *int          `json:"parent_id,omitempty"`
+	Author     *UserCompact  `json:"author,omitempty"`
+	Body       string        `json:"body"`
+	Mentions   []UserCompact `json:"mentions"`
+	ReplyCount int           `json:"reply_count"`
+	Deleted    bool          `json:"deleted"`
+	EditedAt   *time.Time    `json:"edited_at,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type CommentsResponse struct {
+	Results []CommentItem `json:"results"`
+	Meta    PageMeta      `json:"meta"`
+}
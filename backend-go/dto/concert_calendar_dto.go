@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// CalendarConcertsPerDayMax caps how many concerts one calendar day lists. The
+// day's concert_count is always the full count.
+const CalendarConcertsPerDayMax = 20
+
+// ConcertCalendarRequest.Only values. Both need a signed-in user.
+const (
+	CalendarOnlyAttended = "attended" // concerts the user marked or was recorded as attending
+	CalendarOnlyFollowed = "followed" // concerts with an act by an artist the user follows
+)
+
+type ConcertCalendarRequest struct {
+	Year  int    `form:"year" binding:"required,min=1900,max=2100"`
+	Month int    `form:"month" binding:"required,min=1,max=12"`
+	Only  string `form:"only" binding:"omitempty,oneof=attended followed"`
+}
+
+// CalendarConcert is one concert on a calendar day.
+// Artists come from the concert's acts, primary artist first.
+type CalendarConcert struct {
+	ID      int             `json:"id"`
+	Name    string          `json:"name"`
+	Date    time.Time       `json:"date"`
+	Artists []ArtistCompact `json:"artists"`
+	Venue   *VenueCompact   `json:"venue,omitempty"`
+}
+
+type CalendarDay struct {
+	Date         string            `json:"date"` // YYYY-MM-DD
+	ConcertCount int               `json:"concert_count"`
+	Concerts     []CalendarConcert `json:"concerts"`
+}
+
+// ConcertCalendarResponse lists only days that have concerts, in date order.
+type ConcertCalendarResponse struct {
+	Year  int           `json:"year"`
+	Month int           `json:"month"`
+	Days  []CalendarDay `json:"days"`
+}
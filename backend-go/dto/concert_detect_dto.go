@@ -8,10 +8,13 @@ import (
 
 // ConcertDetectRequest holds client-provided metadata used to match against concerts.
 // All fields are optional — results degrade gracefully if some are absent.
+// VideoID, when set to one of the caller's videos, notifies them of the candidates
+// so they can confirm later (POST /videos/:id/concert).
 type ConcertDetectRequest struct {
 	RecordedAt *time.Time `json:"recordedAt"`
 	Latitude   *float64   `json:"latitude"`
 	Longitude  *float64   `json:"longitude"`
+	VideoID    *int       `json:"videoId"`
 }
 
 // ConcertMatch pairs a concert candidate with its detection confidence score.
@@ -26,3 +29,9 @@ type ConcertDetectResult struct {
 	Detected bool           `json:"detected"`
 	Matches  []ConcertMatch `json:"matches"`
 }
+
+// ConcertConfirmRequest for POST /videos/:id/concert: the user accepts one of
+// the detected candidates (or picks a concert by hand) for their video.
+type ConcertConfirmRequest struct {
+	ConcertID int `json:"concertId" binding:"required,min=1"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+type DataExportsResponse struct {
+	Results []*models.DataExport `json:"results"`
+}
+
+// DataExportManifest is manifest.json at the root of a data export archive.
+type DataExportManifest struct {
+	UserID      int              `json:"user_id"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	LinksExpire time.Time        `json:"links_expire_at"` // when the video download links stop working
+	Files       []DataExportFile `json:"files"`
+}
+
+// DataExportFile describes one JSON file in a data export archive.
+type DataExportFile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Records     int    `json:"records"`
+}
+
+// DataExportProfile is profile.json in a data export archive.
+type DataExportProfile struct {
+	User                    models.User             `json:"user"`
+	NotificationPreferences NotificationPreferences `json:"notification_preferences"`
+}
+
+// DataExportVideo is a video in videos.json, with a link to the original upload.
+type DataExportVideo struct {
+	models.Video
+	OriginalURL string `json:"original_url"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// FeedVideoPreviewMax is how many of a concert's new videos a feed card carries.
+const FeedVideoPreviewMax = 3
+
+// Feed item types
+const (
+	FeedItemTypeConcert = "concert" // new activity at a concert
+	FeedItemTypeVideo   = "video"   // a followed user's video with no concert
+)
+
+// FeedItem is one home feed card. Concert cards collapse every new video at the
+// show into VideoCount plus a preview of the newest; a concert newly added for a
+// followed artist has no videos.
+type FeedItem struct {
+	Type       string             `json:"type"`
+	ActivityAt time.Time          `json:"activity_at"`
+	Reasons    []string           `json:"reasons"` // see models.FeedReason*
+	Concert    *ConcertSearchItem `json:"concert,omitempty"`
+	VideoCount int                `json:"video_count"`
+	Videos     []*models.Video    `json:"videos"`
+}
+
+type FeedResponse struct {
+	Results []FeedItem `json:"results"`
+	Meta    PageMeta   `json:"meta"`
+}
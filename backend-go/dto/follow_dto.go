@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const (
+	FollowingTypeUsers   = "users"
+	FollowingTypeArtists = "artists"
+)
+
+// FollowingRequest for GET /users/:username/following. Type defaults to users.
+type FollowingRequest struct {
+	Type string `form:"type" binding:"omitempty,oneof=users artists"`
+	PageRequest
+}
+
+type FollowUserItem struct {
+	ID                int       `json:"id"`
+	Username          string    `json:"username"`
+	DisplayName       string    `json:"display_name"`
+	ProfilePictureURL *string   `json:"profile_picture,omitempty"`
+	FollowedAt        time.Time `json:"followed_at"`
+}
+
+type FollowUsersResponse struct {
+	Results []FollowUserItem `json:"results"`
+	Meta    PageMeta         `json:"meta"`
+}
+
+type FollowArtistItem struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	ImageURL   *string   `json:"image_url,omitempty"`
+	IsVerified bool      `json:"is_verified"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type FollowArtistsResponse struct {
+	Results []FollowArtistItem `json:"results"`
+	Meta    PageMeta           `json:"meta"`
+}
+
+// ArtistDetail is GET /artists/:id: the artist plus follow state.
+// FollowedByMe is only set for authenticated callers.
+type ArtistDetail struct {
+	models.Artist
+	FollowersCount int   `json:"followers_count"`
+	FollowedByMe   *bool `json:"followed_by_me,omitempty"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// NotificationsRequest for GET /notifications.
+type NotificationsRequest struct {
+	Unread bool `form:"unread"` // only unread notifications
+	PageRequest
+}
+
+// NotificationItem is a notification as shown in the inbox, with its actor expanded.
+type NotificationItem struct {
+	ID        int            `json:"id"`
+	Type      string         `json:"type"`
+	Actor     *UserCompact   `json:"actor,omitempty"`
+	VideoID   *int           `json:"video_id,omitempty"`
+	ConcertID *int           `json:"concert_id,omitempty"`
+	CommentID *int           `json:"comment_id,omitempty"`
+	Data      map[string]any `json:"data,omitempty"`
+	Read      bool           `json:"read"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type NotificationsResponse struct {
+	Results     []NotificationItem `json:"results"`
+	UnreadCount int                `json:"unread_count"`
+	Meta        PageMeta           `json:"meta"`
+}
+
+// NotificationPreferences maps each notification type to whether it is enabled.
+// Updates may include only the types that change.
+type NotificationPreferences map[string]bool
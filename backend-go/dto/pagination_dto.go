@@ -0,0 +1,18 @@
+package dto
+
+const (
+	PageLimitDefault = 20
+	PageLimitMax     = 50
+)
+
+// PageRequest is shared across cursor-paginated list endpoints.
+// Cursor is opaque to clients — pass back whatever the previous page returned.
+type PageRequest struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+type PageMeta struct {
+	NextCursor *string `json:"next_cursor,omitempty"`
+	HasMore    bool    `json:"has_more"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/areeeeeeeb/reLive/backend-go/models"
+
+// PersonalTokenExpiryDefault is the lifetime, in days, of tokens created without ExpiresInDays.
+const PersonalTokenExpiryDefault = 90
+
+// CreatePersonalTokenRequest for POST /users/me/tokens.
+// ExpiresInDays defaults to PersonalTokenExpiryDefault; tokens last at most a year.
+type CreatePersonalTokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Scopes        []string `json:"scopes" binding:"required,min=1,dive,oneof=read videos:write"`
+	ExpiresInDays int      `json:"expiresInDays" binding:"omitempty,min=1,max=365"`
+}
+
+// CreatePersonalTokenResponse carries the token itself, which is never shown again.
+type CreatePersonalTokenResponse struct {
+	Token  *models.PersonalAccessToken `json:"token"`
+	Secret string                      `json:"secret"`
+}
+
+type PersonalTokensResponse struct {
+	Results []*models.PersonalAccessToken `json:"results"`
+}
@@ -23,10 +23,23 @@ type ArtistCompact struct {
 	ImageURL *string `json:"image_url,omitempty"`
 }
 
+type UserCompact struct {
+	ID                int     `json:"id"`
+	Username          string  `json:"username"`
+	DisplayName       string  `json:"display_name"`
+	ProfilePictureURL *string `json:"profile_picture,omitempty"`
+}
+
 // SearchRequest is shared across search endpoints.
+// Cursor is the opaque next_cursor from a previous page of the same query.
+// ConcertFilters only applies to concert search and is set by its handler; other endpoints ignore it.
+// ViewerID is the authenticated caller (0 when anonymous), set by handlers for per-viewer flags.
 type SearchRequest struct {
-	Q          string `form:"q" binding:"required"`
-	MaxResults int    `form:"max_results"`
+	Q              string                `form:"q" binding:"required"`
+	MaxResults     int                   `form:"max_results"`
+	Cursor         string                `form:"cursor"`
+	ConcertFilters *ConcertSearchFilters `form:"-"`
+	ViewerID       int                   `form:"-"`
 }
 
 type SearchResponseMeta struct {
@@ -48,6 +61,32 @@ type ConcertSearchItem struct {
 	ImageURL        *string        `json:"image_url,omitempty"`
 }
 
+// ConcertSearchFilters narrow concert search. Dates are YYYY-MM-DD and DateTo is inclusive.
+// HasVideos counts public, uploaded videos only.
+type ConcertSearchFilters struct {
+	DateFrom    *time.Time `form:"date_from" time_format:"2006-01-02" json:"date_from,omitempty"`
+	DateTo      *time.Time `form:"date_to" time_format:"2006-01-02" json:"date_to,omitempty"`
+	City        *string    `form:"city" json:"city,omitempty"`
+	CountryCode *string    `form:"country_code" binding:"omitempty,len=2,alpha" json:"country_code,omitempty"`
+	ArtistID    *int       `form:"artist_id" binding:"omitempty,min=1" json:"artist_id,omitempty"`
+	HasVideos   *bool      `form:"has_videos" json:"has_videos,omitempty"`
+}
+
+// IsEmpty reports whether no filter is set.
+func (f ConcertSearchFilters) IsEmpty() bool {
+	return f.DateFrom == nil && f.DateTo == nil && f.City == nil &&
+		f.CountryCode == nil && f.ArtistID == nil && f.HasVideos == nil
+}
+
+// ConcertSearchRequest is the query for GET /concerts/search.
+// Unlike the other search endpoints, q is optional when at least one filter is set.
+type ConcertSearchRequest struct {
+	Q          string `form:"q"`
+	MaxResults int    `form:"max_results"`
+	Cursor     string `form:"cursor"`
+	ConcertSearchFilters
+}
+
 type ConcertSearchResponse struct {
 	Results []ConcertSearchItem `json:"results"`
 	Meta    SearchResponseMeta  `json:"meta"`
@@ -55,11 +94,13 @@ type ConcertSearchResponse struct {
 
 // -----ARTIST SEARCH
 
+// FollowedByMe is only set for authenticated callers.
 type ArtistSearchItem struct {
-	ID         int     `json:"id"`
-	Name       string  `json:"name"`
-	ImageURL   *string `json:"image_url,omitempty"`
-	IsVerified bool    `json:"is_verified"`
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	ImageURL     *string `json:"image_url,omitempty"`
+	IsVerified   bool    `json:"is_verified"`
+	FollowedByMe *bool   `json:"followed_by_me,omitempty"`
 }
 
 type ArtistSearchResponse struct {
@@ -84,14 +125,80 @@ type SongSearchResponse struct {
 
 // -----USER SEARCH
 
+// FollowedByMe is only set for authenticated callers.
 type UserSearchItem struct {
 	ID                int     `json:"id"`
 	Username          string  `json:"username"`
 	DisplayName       string  `json:"display_name"`
 	ProfilePictureURL *string `json:"profile_picture,omitempty"`
+	FollowedByMe      *bool   `json:"followed_by_me,omitempty"`
 }
 
 type UserSearchResponse struct {
 	Results []UserSearchItem   `json:"results"`
 	Meta    SearchResponseMeta `json:"meta"`
 }
+
+// -----VENUE SEARCH
+
+type VenueSearchItem struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	City        *string `json:"city,omitempty"`
+	Region      *string `json:"region,omitempty"`
+	CountryCode string  `json:"country_code"`
+}
+
+type VenueSearchResponse struct {
+	Results []VenueSearchItem  `json:"results"`
+	Meta    SearchResponseMeta `json:"meta"`
+}
+
+// -----UNIFIED SEARCH
+
+const (
+	UnifiedSearchLimitDefault = 5
+	UnifiedSearchLimitMax     = 20
+	UnifiedSearchTopHitsMax   = 5
+)
+
+// Unified search section names, accepted in UnifiedSearchRequest.Types and reported as TopHit.Type.
+const (
+	SearchTypeArtists  = "artists"
+	SearchTypeSongs    = "songs"
+	SearchTypeConcerts = "concerts"
+	SearchTypeUsers    = "users"
+)
+
+// UnifiedSearchRequest runs several entity searches at once.
+// Limit applies to each section; Types is a comma-separated subset of sections (all when empty).
+type UnifiedSearchRequest struct {
+	Q        string `form:"q" binding:"required"`
+	Limit    int    `form:"limit"`
+	Types    string `form:"types"`
+	ViewerID int    `form:"-"`
+}
+
+// TopHit is one entry in the merged top-hits list. Exactly one item pointer is set, matching Type.
+// Score is normalized to [0, 1] so hits from different sections are comparable.
+type TopHit struct {
+	Type    string             `json:"type"`
+	Score   float64            `json:"score"`
+	Artist  *ArtistSearchItem  `json:"artist,omitempty"`
+	Song    *SongSearchItem    `json:"song,omitempty"`
+	Concert *ConcertSearchItem `json:"concert,omitempty"`
+	User    *UserSearchItem    `json:"user,omitempty"`
+}
+
+// UnifiedSearchResponse holds one section per requested type. A section that
+// errored or ran out of time is omitted and listed in FailedSections.
+// Each section's meta.next_cursor continues on that entity's own search endpoint.
+type UnifiedSearchResponse struct {
+	Query          string                 `json:"query"`
+	TopHits        []TopHit               `json:"top_hits"`
+	Artists        *ArtistSearchResponse  `json:"artists,omitempty"`
+	Songs          *SongSearchResponse    `json:"songs,omitempty"`
+	Concerts       *ConcertSearchResponse `json:"concerts,omitempty"`
+	Users          *UserSearchResponse    `json:"users,omitempty"`
+	FailedSections []string               `json:"failed_sections,omitempty"`
+}
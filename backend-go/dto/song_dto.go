@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/areeeeeeeb/reLive/backend-go/models"
+
+// SongPerformanceItem is one live performance of a song.
+// IsCover is true when the performer is not the song's credited artist.
+type SongPerformanceItem struct {
+	ID        int               `json:"id"`
+	Position  *int              `json:"position,omitempty"`
+	IsCover   bool              `json:"is_cover"`
+	Performer *ArtistCompact    `json:"performer,omitempty"`
+	Concert   ConcertSearchItem `json:"concert"`
+}
+
+type SongPerformancesResponse struct {
+	Results []SongPerformanceItem `json:"results"`
+	Meta    PageMeta              `json:"meta"`
+}
+
+type SongStatsResponse struct {
+	Stats models.SongPerformanceStats `json:"stats"`
+}
@@ -1,6 +1,9 @@
 package dto
 
-// SyncUserRequest for syncing user from Auth0 on login
+import "time"
+
+// SyncUserRequest for syncing user from Auth0 on login.
+// Username only applies until the user picks one in the app (PATCH /users/me/username).
 type SyncUserRequest struct {
 	Email       string `json:"email" binding:"required,email"`
 	Username    string `json:"username" binding:"required"`
@@ -15,6 +18,12 @@ type TestSyncRequest struct {
 	DisplayName string `json:"displayName"`
 }
 
+// UpdatePrivacyRequest for PATCH /users/me/privacy. Omitted fields are left unchanged.
+// LocationPrivacy controls how other viewers see the coordinates of the user's videos.
+type UpdatePrivacyRequest struct {
+	LocationPrivacy *string `json:"locationPrivacy" binding:"omitempty,oneof=precise approximate hidden"`
+}
+
 // UpdateProfileRequest for updating user profile in-app.
 // ProfilePicture and Bio are pointers — JSON null explicitly clears the field.
 type UpdateProfileRequest struct {
@@ -22,3 +31,27 @@ type UpdateProfileRequest struct {
 	ProfilePicture *string `json:"profilePicture"`
 	Bio            *string `json:"bio"`
 }
+
+// SetUsernameRequest for PATCH /users/me/username.
+type SetUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+// UsernameAvailabilityResponse for GET /users/username-available.
+// Reason explains why an unavailable username can't be used.
+type UsernameAvailabilityResponse struct {
+	Username  string `json:"username"`
+	Available bool   `json:"available"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// LinkCodeResponse for POST /users/me/link-code. The code is shown once.
+type LinkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LinkAccountRequest for POST /users/link, sent while signed in with the identity to link.
+type LinkAccountRequest struct {
+	Code string `json:"code" binding:"required"`
+}
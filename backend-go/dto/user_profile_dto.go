@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// UserProfile is the public view of a user. It omits auth0_id and email.
+// Counts only include what the viewer may see.
+type UserProfile struct {
+	ID                int                      `json:"id"`
+	Username          string                   `json:"username"`
+	DisplayName       string                   `json:"display_name"`
+	ProfilePictureURL *string                  `json:"profile_picture,omitempty"`
+	Bio               *string                  `json:"bio,omitempty"`
+	JoinedAt          time.Time                `json:"joined_at"`
+	Counts            models.UserProfileCounts `json:"counts"`
+	FollowedByMe      *bool                    `json:"followed_by_me,omitempty"` // only set for authenticated callers
+}
+
+type UserVideosResponse struct {
+	Results []*models.Video `json:"results"`
+	Meta    PageMeta        `json:"meta"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/areeeeeeeb/reLive/backend-go/models"
+
+const (
+	NearbyRadiusMetersDefault = 5_000
+	NearbyRadiusMetersMax     = 50_000
+)
+
+// NearbyVenuesRequest for GET /venues/nearby.
+// Lat/Lng are pointers so 0 (equator / prime meridian) still counts as provided.
+type NearbyVenuesRequest struct {
+	Lat          *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng          *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusMeters float64  `form:"radius_m"`
+	MaxResults   int      `form:"max_results"`
+}
+
+type NearbyVenuesResponse struct {
+	Results []models.NearbyVenue `json:"results"`
+}
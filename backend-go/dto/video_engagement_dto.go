@@ -0,0 +1,6 @@
+package dto
+
+// ReactRequest for PUT /videos/:id/reaction. Reacting again replaces the previous reaction.
+type ReactRequest struct {
+	Reaction string `json:"reaction" binding:"required,oneof=like fire heart laugh wow cry"`
+}
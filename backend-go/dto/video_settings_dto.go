@@ -0,0 +1,9 @@
+package dto
+
+// UpdateVideoRequest for PATCH /videos/:id. Omitted fields are left unchanged;
+// an empty caption clears it and concertId 0 unlinks the video from its concert.
+type UpdateVideoRequest struct {
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=public unlisted private"`
+	Caption    *string `json:"caption" binding:"omitempty,max=2000"`
+	ConcertID  *int    `json:"concertId" binding:"omitempty,min=0"`
+}
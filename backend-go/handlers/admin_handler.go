@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminHandler struct {
+	adminService           *services.AdminService
+	accountDeletionService *services.AccountDeletionService
+}
+
+func NewAdminHandler(adminService *services.AdminService, accountDeletionService *services.AccountDeletionService) *AdminHandler {
+	return &AdminHandler{adminService: adminService, accountDeletionService: accountDeletionService}
+}
+
+// SetRole changes a user's role. Admin only.
+//
+//	PUT /admin/users/:username/role  {"role": "moderator", "reason": "..."}
+func (h *AdminHandler) SetRole(c *gin.Context) {
+	var req dto.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminService.SetRole(c.Request.Context(), c.GetInt("user_id"), c.Param("username"), req)
+	if err != nil {
+		respondAdminError(c, err, "user not found", "failed to set role")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// ListRoleChanges returns a user's role audit trail, newest first. Admin only.
+//
+//	GET /admin/users/:username/role-changes?cursor=&limit=20
+func (h *AdminHandler) ListRoleChanges(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.adminService.ListRoleChanges(c.Request.Context(), c.Param("username"), req)
+	if err != nil {
+		respondAdminError(c, err, "user not found", "failed to list role changes")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListCommentReports returns the comment moderation queue, oldest first.
+//
+//	GET /admin/comment-reports?status=open&cursor=&limit=20
+func (h *AdminHandler) ListCommentReports(c *gin.Context) {
+	var req dto.CommentReportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.adminService.ListCommentReports(c.Request.Context(), req)
+	if err != nil {
+		respondAdminError(c, err, "report not found", "failed to list comment reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResolveCommentReport dismisses a report or removes the reported comment,
+// closing every open report of that comment.
+//
+//	POST /admin/comment-reports/:id/resolve  {"action": "remove"}
+func (h *AdminHandler) ResolveCommentReport(c *gin.Context) {
+	reportID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid report id"})
+		return
+	}
+
+	var req dto.ResolveCommentReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resolved, err := h.adminService.ResolveCommentReport(c.Request.Context(), c.GetInt("user_id"), reportID, req)
+	if err != nil {
+		respondAdminError(c, err, "report not found", "failed to resolve report")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"resolved": resolved})
+}
+
+// MergeUsers folds the account in the path into another account that shares a
+// verified email with it. Admin only.
+//
+//	POST /admin/users/:username/merge  {"into": "dkang"}
+func (h *AdminHandler) MergeUsers(c *gin.Context) {
+	var req dto.MergeUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminService.MergeUsers(c.Request.Context(), c.Param("username"), req.Into)
+	if err != nil {
+		respondAdminError(c, err, "user not found", "failed to merge users")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// RestoreUser brings back an account deleted with DELETE /users/me, as long as its
+// grace period hasn't run out and the purge hasn't started. Admin only.
+//
+//	POST /admin/users/:username/restore
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	user, err := h.accountDeletionService.Restore(c.Request.Context(), c.Param("username"))
+	if err != nil {
+		respondAdminError(c, err, "no restorable deleted account with that username", "failed to restore user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+func respondAdminError(c *gin.Context, err error, notFound string, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, apperr.ErrSelfRoleChange):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrSelfMerge):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrReportResolved), errors.Is(err, apperr.ErrMergeEmailMismatch):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
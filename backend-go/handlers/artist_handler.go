@@ -27,7 +27,7 @@ func (h *ArtistHandler) Get(c *gin.Context) {
 		return
 	}
 
-	artist, err := h.artistService.Get(c.Request.Context(), id)
+	artist, err := h.artistService.Get(c.Request.Context(), id, c.GetInt("user_id"))
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "artist not found"})
@@ -56,11 +56,102 @@ func (h *ArtistHandler) Search(c *gin.Context) {
 		req.MaxResults = dto.SearchMaxResultsMax
 	}
 
+	req.ViewerID = c.GetInt("user_id")
+
 	response, err := h.artistService.Search(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "artist search failed"})
+		respondSearchError(c, err, "artist search failed")
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// ListConcerts returns the artist's concerts split into upcoming and past.
+//
+//	GET /artists/:id/concerts?when=past&limit=20&cursor=...
+func (h *ArtistHandler) ListConcerts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	var req dto.ArtistConcertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.artistService.ListConcerts(c.Request.Context(), id, req)
+	if err != nil {
+		respondArtistListError(c, err, "failed to list artist concerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListSongs returns songs the artist has performed, with times-performed counts.
+//
+//	GET /artists/:id/songs?limit=20&cursor=...
+func (h *ArtistHandler) ListSongs(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.artistService.ListSongs(c.Request.Context(), id, req)
+	if err != nil {
+		respondArtistListError(c, err, "failed to list artist songs")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListVideos returns public videos of the artist, newest first.
+//
+//	GET /artists/:id/videos?limit=20&cursor=...
+func (h *ArtistHandler) ListVideos(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.artistService.ListVideos(c.Request.Context(), id, c.GetInt("user_id"), req)
+	if err != nil {
+		respondArtistListError(c, err, "failed to list artist videos")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func respondArtistListError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "artist not found"})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
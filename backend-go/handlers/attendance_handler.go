@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceHandler struct {
+	attendanceService *services.AttendanceService
+}
+
+func NewAttendanceHandler(attendanceService *services.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{attendanceService: attendanceService}
+}
+
+// Mark records that the current user attended a concert.
+//
+//	POST /concerts/:id/attendance  {"visibility": "private"}
+func (h *AttendanceHandler) Mark(c *gin.Context) {
+	concertID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid concert id"})
+		return
+	}
+
+	var req dto.MarkAttendanceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	attendance, err := h.attendanceService.Mark(c.Request.Context(), c.GetInt("user_id"), concertID, req.Visibility)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "concert not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark attendance"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attendance": attendance})
+}
+
+// Unmark removes the current user's attendance at a concert.
+//
+//	DELETE /concerts/:id/attendance
+func (h *AttendanceHandler) Unmark(c *gin.Context) {
+	concertID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid concert id"})
+		return
+	}
+
+	if err := h.attendanceService.Unmark(c.Request.Context(), c.GetInt("user_id"), concertID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove attendance"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListAttendees returns a concert's attendees, most recent first. Private
+// attendance is hidden from everyone but the attendee.
+//
+//	GET /concerts/:id/attendees?cursor=&limit=20
+func (h *AttendanceHandler) ListAttendees(c *gin.Context) {
+	concertID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid concert id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.attendanceService.ListAttendees(c.Request.Context(), concertID, c.GetInt("user_id"), req)
+	if err != nil {
+		respondAttendanceListError(c, err, "concert not found", "failed to list attendees")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListMyConcerts returns the concerts the current user attended, including private ones.
+//
+//	GET /users/me/concerts?cursor=&limit=20
+func (h *AttendanceHandler) ListMyConcerts(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.attendanceService.ListUserConcerts(c.Request.Context(), c.GetInt("user_id"), true, req)
+	if err != nil {
+		respondAttendanceListError(c, err, "user not found", "failed to list concerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListUserConcerts returns the concerts a user publicly attended.
+//
+//	GET /users/:username/concerts?cursor=&limit=20
+func (h *AttendanceHandler) ListUserConcerts(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.attendanceService.ListUserConcertsByUsername(c.Request.Context(), c.Param("username"), req)
+	if err != nil {
+		respondAttendanceListError(c, err, "user not found", "failed to list concerts")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func respondAttendanceListError(c *gin.Context, err error, notFound string, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	commentService *services.CommentService
+}
+
+func NewCommentHandler(commentService *services.CommentService) *CommentHandler {
+	return &CommentHandler{commentService: commentService}
+}
+
+// ListVideoComments returns a video's top-level comments, newest first.
+// Comments on an unlisted video open for anyone passing its share token.
+//
+//	GET /videos/:id/comments?cursor=&limit=20&share=
+func (h *CommentHandler) ListVideoComments(c *gin.Context) {
+	h.list(c, models.CommentTargetVideo, "video not found")
+}
+
+// ListConcertComments returns a concert's top-level comments, newest first.
+//
+//	GET /concerts/:id/comments?cursor=&limit=20
+func (h *CommentHandler) ListConcertComments(c *gin.Context) {
+	h.list(c, models.CommentTargetConcert, "concert not found")
+}
+
+// CreateVideoComment comments on a video, or replies to one of its comments.
+//
+//	POST /videos/:id/comments?share=  {"body": "what a set @sam", "parent_id": 12}
+func (h *CommentHandler) CreateVideoComment(c *gin.Context) {
+	h.create(c, models.CommentTargetVideo, "video or parent comment not found")
+}
+
+// CreateConcertComment comments on a concert, or replies to one of its comments.
+//
+//	POST /concerts/:id/comments  {"body": "...", "parent_id": 12}
+func (h *CommentHandler) CreateConcertComment(c *gin.Context) {
+	h.create(c, models.CommentTargetConcert, "concert or parent comment not found")
+}
+
+// ListReplies returns a comment's replies, oldest first.
+//
+//	GET /comments/:id/replies?cursor=&limit=20&share=
+func (h *CommentHandler) ListReplies(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.commentService.ListReplies(c.Request.Context(), commentID, c.GetInt("user_id"), c.Query("share"), req)
+	if err != nil {
+		respondCommentError(c, err, "comment not found", "failed to list replies")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Update edits the current user's comment.
+//
+//	PATCH /comments/:id  {"body": "..."}
+func (h *CommentHandler) Update(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.Update(c.Request.Context(), c.GetInt("user_id"), commentID, req.Body)
+	if err != nil {
+		respondCommentError(c, err, "comment not found", "failed to update comment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comment": comment})
+}
+
+// Delete soft-deletes the current user's comment.
+//
+//	DELETE /comments/:id
+func (h *CommentHandler) Delete(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return
+	}
+
+	if err := h.commentService.Delete(c.Request.Context(), c.GetInt("user_id"), commentID); err != nil {
+		respondCommentError(c, err, "comment not found", "failed to delete comment")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Report sends a comment to the moderation queue.
+//
+//	POST /comments/:id/report?share=  {"reason": "spam", "details": "..."}
+func (h *CommentHandler) Report(c *gin.Context) {
+	commentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid comment id"})
+		return
+	}
+
+	var req dto.ReportCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.commentService.Report(c.Request.Context(), c.GetInt("user_id"), commentID, c.Query("share"), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrDuplicate) {
+			c.JSON(http.StatusConflict, gin.H{"error": "comment already reported"})
+			return
+		}
+		respondCommentError(c, err, "comment not found", "failed to report comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"report": report})
+}
+
+func (h *CommentHandler) list(c *gin.Context, targetType string, notFound string) {
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + targetType + " id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.commentService.List(c.Request.Context(), targetType, targetID, c.GetInt("user_id"), c.Query("share"), req)
+	if err != nil {
+		respondCommentError(c, err, notFound, "failed to list comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CommentHandler) create(c *gin.Context, targetType string, notFound string) {
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + targetType + " id"})
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comment, err := h.commentService.Create(c.Request.Context(), c.GetInt("user_id"), targetType, targetID, c.Query("share"), req)
+	if err != nil {
+		respondCommentError(c, err, notFound, "failed to create comment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"comment": comment})
+}
+
+func respondCommentError(c *gin.Context, err error, notFound string, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, apperr.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": "not allowed"})
+	case errors.Is(err, apperr.ErrEmptyComment):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
@@ -55,15 +56,27 @@ func (h *ConcertHandler) Get(c *gin.Context) {
 	c.JSON(200, gin.H{"concert": result})
 }
 
-// Search returns concerts matching a query string.
+// Search returns concerts matching a query string and/or filters.
+// q may be omitted when at least one filter is set.
 //
 //	GET /concerts/search?q=eras+tour&max_results=10
+//	GET /concerts/search?city=toronto&date_from=2025-01-01&date_to=2025-12-31
 func (h *ConcertHandler) Search(c *gin.Context) {
-	var req dto.SearchRequest
+	var req dto.ConcertSearchRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.City = trimOptional(req.City)
+	req.CountryCode = trimOptional(req.CountryCode)
+	if strings.TrimSpace(req.Q) == "" && req.IsEmpty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "q or at least one filter is required"})
+		return
+	}
+	if req.DateFrom != nil && req.DateTo != nil && req.DateTo.Before(*req.DateFrom) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date_to must not be before date_from"})
+		return
+	}
 	if req.MaxResults <= 0 {
 		req.MaxResults = dto.SearchMaxResultsDefault
 	}
@@ -71,9 +84,14 @@ func (h *ConcertHandler) Search(c *gin.Context) {
 		req.MaxResults = dto.SearchMaxResultsMax
 	}
 
-	response, err := h.concertService.Search(c.Request.Context(), req)
+	response, err := h.concertService.Search(c.Request.Context(), dto.SearchRequest{
+		Q:              req.Q,
+		MaxResults:     req.MaxResults,
+		Cursor:         req.Cursor,
+		ConcertFilters: &req.ConcertSearchFilters,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "concert search failed"})
+		respondSearchError(c, err, "concert search failed")
 		return
 	}
 
@@ -129,7 +147,7 @@ func (h *ConcertHandler) Detect(c *gin.Context) {
 		return
 	}
 
-	result, err := h.detectionService.DetectConcert(c.Request.Context(), req)
+	result, err := h.detectionService.DetectConcert(c.Request.Context(), c.GetInt("user_id"), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -145,7 +163,7 @@ func (h *ConcertHandler) ListVideos(c *gin.Context) {
 		return
 	}
 
-	result, err := h.videoService.ListByConcert(c.Request.Context(), concertID)
+	result, err := h.videoService.ListByConcert(c.Request.Context(), concertID, c.GetInt("user_id"))
 	if err != nil {
 		if errors.Is(err, apperr.ErrNotFound) {
 			c.JSON(404, gin.H{"error": "concert not found"})
@@ -157,3 +175,43 @@ func (h *ConcertHandler) ListVideos(c *gin.Context) {
 
 	c.JSON(200, gin.H{"videos": result})
 }
+
+// Calendar returns a month of concerts grouped by day.
+// only=attended|followed narrows to the signed-in user's concerts, so it is only
+// honored on the authenticated route.
+//
+//	GET /concerts/calendar?year=2025&month=7
+//	GET /concerts/calendar/mine?year=2025&month=7&only=followed
+func (h *ConcertHandler) Calendar(c *gin.Context) {
+	var req dto.ConcertCalendarRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetInt("user_id")
+	if req.Only != "" && userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "sign in to filter the calendar"})
+		return
+	}
+
+	response, err := h.concertService.Calendar(c.Request.Context(), req, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get calendar"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// trimOptional trims an optional query value, treating a blank value as absent.
+func trimOptional(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type DataExportHandler struct {
+	dataExportService *services.DataExportService
+}
+
+func NewDataExportHandler(dataExportService *services.DataExportService) *DataExportHandler {
+	return &DataExportHandler{dataExportService: dataExportService}
+}
+
+// Request queues an archive of the current user's data. The user is notified with a
+// download link when it is ready.
+//
+//	POST /users/me/exports
+func (h *DataExportHandler) Request(c *gin.Context) {
+	export, err := h.dataExportService.Request(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrDuplicate) {
+			c.JSON(http.StatusConflict, gin.H{"error": "an export is already in progress"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to request export"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"export": export})
+}
+
+// List returns the current user's recent exports, newest first.
+//
+//	GET /users/me/exports
+func (h *DataExportHandler) List(c *gin.Context) {
+	response, err := h.dataExportService.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list exports"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Get returns one of the current user's exports, with a fresh download link once it is completed.
+//
+//	GET /users/me/exports/:id
+func (h *DataExportHandler) Get(c *gin.Context) {
+	exportID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid export id"})
+		return
+	}
+
+	export, err := h.dataExportService.Get(c.Request.Context(), c.GetInt("user_id"), exportID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "export not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get export"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"export": export})
+}
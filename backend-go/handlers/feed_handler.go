@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	feedService *services.FeedService
+}
+
+func NewFeedHandler(feedService *services.FeedService) *FeedHandler {
+	return &FeedHandler{feedService: feedService}
+}
+
+// Home returns the current user's home feed: new videos from followed users, new
+// videos at concerts they attended, and new concerts by followed artists, one card per concert.
+//
+//	GET /users/me/feed?cursor=&limit=20
+func (h *FeedHandler) Home(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.feedService.Home(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load feed"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type FollowHandler struct {
+	followService *services.FollowService
+}
+
+func NewFollowHandler(followService *services.FollowService) *FollowHandler {
+	return &FollowHandler{followService: followService}
+}
+
+// FollowUser makes the current user follow another user.
+//
+//	POST /users/:username/follow
+func (h *FollowHandler) FollowUser(c *gin.Context) {
+	err := h.followService.FollowUser(c.Request.Context(), c.GetInt("user_id"), c.Param("username"))
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case errors.Is(err, apperr.ErrSelfFollow):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, apperr.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"error": "not allowed"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to follow user"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowUser removes the current user's follow of another user.
+//
+//	DELETE /users/:username/follow
+func (h *FollowHandler) UnfollowUser(c *gin.Context) {
+	err := h.followService.UnfollowUser(c.Request.Context(), c.GetInt("user_id"), c.Param("username"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unfollow user"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// BlockUser makes the current user block another user. Follows between them are removed.
+//
+//	POST /users/:username/block
+func (h *FollowHandler) BlockUser(c *gin.Context) {
+	err := h.followService.BlockUser(c.Request.Context(), c.GetInt("user_id"), c.Param("username"))
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		case errors.Is(err, apperr.ErrSelfBlock):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to block user"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnblockUser removes the current user's block of another user.
+//
+//	DELETE /users/:username/block
+func (h *FollowHandler) UnblockUser(c *gin.Context) {
+	err := h.followService.UnblockUser(c.Request.Context(), c.GetInt("user_id"), c.Param("username"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unblock user"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// FollowArtist makes the current user follow an artist.
+//
+//	POST /artists/:id/follow
+func (h *FollowHandler) FollowArtist(c *gin.Context) {
+	artistID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	if err := h.followService.FollowArtist(c.Request.Context(), c.GetInt("user_id"), artistID); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "artist not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to follow artist"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnfollowArtist removes the current user's follow of an artist.
+//
+//	DELETE /artists/:id/follow
+func (h *FollowHandler) UnfollowArtist(c *gin.Context) {
+	artistID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	if err := h.followService.UnfollowArtist(c.Request.Context(), c.GetInt("user_id"), artistID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unfollow artist"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Followers returns the users following a user, most recent first.
+//
+//	GET /users/:username/followers?cursor=&limit=20
+func (h *FollowHandler) Followers(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.followService.ListFollowers(c.Request.Context(), c.Param("username"), req)
+	if err != nil {
+		respondFollowListError(c, err, "user not found", "failed to list followers")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Following returns the users (default) or artists a user follows, most recent first.
+//
+//	GET /users/:username/following?type=artists&cursor=&limit=20
+func (h *FollowHandler) Following(c *gin.Context) {
+	var req dto.FollowingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	var response any
+	var err error
+	if req.Type == dto.FollowingTypeArtists {
+		response, err = h.followService.ListFollowingArtists(c.Request.Context(), c.Param("username"), req.PageRequest)
+	} else {
+		response, err = h.followService.ListFollowingUsers(c.Request.Context(), c.Param("username"), req.PageRequest)
+	}
+	if err != nil {
+		respondFollowListError(c, err, "user not found", "failed to list following")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ArtistFollowers returns the users following an artist, most recent first.
+//
+//	GET /artists/:id/followers?cursor=&limit=20
+func (h *FollowHandler) ArtistFollowers(c *gin.Context) {
+	artistID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid artist id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.followService.ListArtistFollowers(c.Request.Context(), artistID, req)
+	if err != nil {
+		respondFollowListError(c, err, "artist not found", "failed to list followers")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func respondFollowListError(c *gin.Context, err error, notFound string, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type IdentityHandler struct {
+	identityService *services.IdentityService
+}
+
+func NewIdentityHandler(identityService *services.IdentityService) *IdentityHandler {
+	return &IdentityHandler{identityService: identityService}
+}
+
+// List returns the identities the current user can sign in with.
+//
+//	GET /users/me/identities
+func (h *IdentityHandler) List(c *gin.Context) {
+	identities, err := h.identityService.ListIdentities(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list identities"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"identities": identities})
+}
+
+// Unlink detaches one of the current user's identities. The subject is URL-encoded
+// (e.g. google-oauth2%7C1234).
+//
+//	DELETE /users/me/identities/:subject
+func (h *IdentityHandler) Unlink(c *gin.Context) {
+	err := h.identityService.Unlink(c.Request.Context(), c.GetInt("user_id"), c.GetString("auth0_id"), c.Param("subject"))
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "identity not found"})
+		case errors.Is(err, apperr.ErrUnlinkCurrentIdentity):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to unlink identity"})
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateLinkCode issues a short-lived one-time code for linking another identity to
+// the current user's account.
+//
+//	POST /users/me/link-code
+func (h *IdentityHandler) CreateLinkCode(c *gin.Context) {
+	resp, err := h.identityService.CreateLinkCode(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create link code"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// Link redeems a link code with the identity the caller is signed in with. If that
+// identity already has its own account, the account is merged into the code's.
+//
+//	POST /users/link  {"code": "..."}
+func (h *IdentityHandler) Link(c *gin.Context) {
+	var req dto.LinkAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.identityService.Link(c.Request.Context(), c.GetString("auth0_id"), bearerToken(c), req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "invalid or expired link code"})
+		case errors.Is(err, apperr.ErrAccountDeleted):
+			c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+		case errors.Is(err, apperr.ErrDuplicate):
+			c.JSON(http.StatusConflict, gin.H{"error": "identity is already linked to an account"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to link account"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// bearerToken returns the caller's raw access token, or "" when there is none
+// (e.g. with DEV_BYPASS_AUTH).
+func bearerToken(c *gin.Context) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
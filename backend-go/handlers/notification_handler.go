@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// List returns the current user's notifications, newest first, with their unread count.
+//
+//	GET /notifications?unread=true&cursor=&limit=20
+func (h *NotificationHandler) List(c *gin.Context) {
+	var req dto.NotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.notificationService.List(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		respondNotificationError(c, err, "failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UnreadCount returns how many of the current user's notifications are unread.
+//
+//	GET /notifications/unread-count
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	count, err := h.notificationService.UnreadCount(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread_count": count})
+}
+
+// MarkRead marks one of the current user's notifications as read.
+//
+//	POST /notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	notificationID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid notification id"})
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), c.GetInt("user_id"), notificationID); err != nil {
+		respondNotificationError(c, err, "failed to mark notification read")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllRead marks all of the current user's notifications as read.
+//
+//	POST /notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	if err := h.notificationService.MarkAllRead(c.Request.Context(), c.GetInt("user_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark notifications read"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetPreferences returns which notification types the current user receives.
+//
+//	GET /notifications/preferences
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	prefs, err := h.notificationService.Preferences(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+// UpdatePreferences turns notification types on or off. Types left out keep their setting.
+//
+//	PUT /notifications/preferences  {"comment": false, "follow": true}
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req dto.NotificationPreferences
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefs, err := h.notificationService.UpdatePreferences(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		respondNotificationError(c, err, "failed to update preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": prefs})
+}
+
+func respondNotificationError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "notification not found"})
+	case errors.Is(err, apperr.ErrInvalidNotificationType):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// clampPageLimit applies the default and maximum page size to a requested limit.
+func clampPageLimit(limit int) int {
+	if limit <= 0 {
+		return dto.PageLimitDefault
+	}
+	if limit > dto.PageLimitMax {
+		return dto.PageLimitMax
+	}
+	return limit
+}
+
+// respondSearchError maps a search service error to a response.
+// A bad cursor is the client's fault; anything else is reported with the given fallback message.
+func respondSearchError(c *gin.Context, err error, fallback string) {
+	if errors.Is(err, apperr.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalTokenHandler struct {
+	personalTokenService *services.PersonalTokenService
+}
+
+func NewPersonalTokenHandler(personalTokenService *services.PersonalTokenService) *PersonalTokenHandler {
+	return &PersonalTokenHandler{personalTokenService: personalTokenService}
+}
+
+// Create issues a personal access token for the current user. The secret in the
+// response is the only time the token is shown.
+//
+//	POST /users/me/tokens  {"name": "archive uploader", "scopes": ["videos:write"], "expiresInDays": 30}
+func (h *PersonalTokenHandler) Create(c *gin.Context) {
+	var req dto.CreatePersonalTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.personalTokenService.Create(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create token"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// List returns the current user's personal access tokens, newest first.
+//
+//	GET /users/me/tokens
+func (h *PersonalTokenHandler) List(c *gin.Context) {
+	response, err := h.personalTokenService.List(c.Request.Context(), c.GetInt("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Revoke revokes one of the current user's personal access tokens.
+//
+//	DELETE /users/me/tokens/:id
+func (h *PersonalTokenHandler) Revoke(c *gin.Context) {
+	tokenID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token id"})
+		return
+	}
+
+	if err := h.personalTokenService.Revoke(c.Request.Context(), c.GetInt("user_id"), tokenID); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "token not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke token"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
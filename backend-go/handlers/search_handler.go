@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	unifiedSearchService *services.UnifiedSearchService
+}
+
+func NewSearchHandler(unifiedSearchService *services.UnifiedSearchService) *SearchHandler {
+	return &SearchHandler{unifiedSearchService: unifiedSearchService}
+}
+
+// Search runs artist, song, concert and user search in one request,
+// returning a section per type plus a merged top-hits list.
+//
+//	GET /search?q=radiohead&limit=5&types=artists,songs
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req dto.UnifiedSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = dto.UnifiedSearchLimitDefault
+	}
+	if req.Limit > dto.UnifiedSearchLimitMax {
+		req.Limit = dto.UnifiedSearchLimitMax
+	}
+
+	req.ViewerID = c.GetInt("user_id")
+
+	response, err := h.unifiedSearchService.Search(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, apperr.ErrInvalidSearchType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		respondSearchError(c, err, "search failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
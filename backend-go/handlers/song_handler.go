@@ -60,9 +60,65 @@ func (h *SongHandler) Search(c *gin.Context) {
 
 	response, err := h.songService.Search(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "song search failed"})
+		respondSearchError(c, err, "song search failed")
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// ListPerformances returns every concert the song was played at, most recent first.
+//
+//	GET /songs/:id/performances?limit=20&cursor=...
+func (h *SongHandler) ListPerformances(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid song id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.songService.ListPerformances(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "song not found"})
+		case errors.Is(err, apperr.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list song performances"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Stats returns how often and where in the setlist a song is played live.
+//
+//	GET /songs/:id/stats
+func (h *SongHandler) Stats(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid song id"})
+		return
+	}
+
+	stats, err := h.songService.Stats(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "song not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get song stats"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SongStatsResponse{Stats: *stats})
+}
@@ -1,19 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
 	"github.com/areeeeeeeb/reLive/backend-go/services"
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	userService *services.UserService
+	userService            *services.UserService
+	identityService        *services.IdentityService
+	accountDeletionService *services.AccountDeletionService
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService *services.UserService, identityService *services.IdentityService, accountDeletionService *services.AccountDeletionService) *UserHandler {
+	return &UserHandler{userService: userService, identityService: identityService, accountDeletionService: accountDeletionService}
 }
 
 func (h *UserHandler) Sync(c *gin.Context) {
@@ -28,9 +32,9 @@ func (h *UserHandler) Sync(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.Sync(c.Request.Context(), c.GetString("auth0_id"), req.Email, req.Username, req.DisplayName)
+	user, err := h.identityService.Sync(c.Request.Context(), c.GetString("auth0_id"), bearerToken(c), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondSyncError(c, err)
 		return
 	}
 
@@ -45,9 +49,13 @@ func (h *UserHandler) TestSync(c *gin.Context) {
 		return
 	}
 
-	user, err := h.userService.Sync(c.Request.Context(), req.Auth0ID, req.Email, req.Username, req.DisplayName)
+	user, err := h.identityService.Sync(c.Request.Context(), req.Auth0ID, "", dto.SyncUserRequest{
+		Email:       req.Email,
+		Username:    req.Username,
+		DisplayName: req.DisplayName,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondSyncError(c, err)
 		return
 	}
 
@@ -65,6 +73,65 @@ func (h *UserHandler) Me(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
+// Profile returns a user's public profile with activity counts.
+// Signed-in owners see counts that include their private videos and attendance.
+//
+//	GET /users/:username
+func (h *UserHandler) Profile(c *gin.Context) {
+	profile, err := h.userService.GetProfile(c.Request.Context(), c.Param("username"), c.GetInt("user_id"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get profile"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"profile": profile})
+}
+
+// ListVideos returns a user's videos, newest first. Other viewers only see
+// public, uploaded videos; the owner sees everything.
+//
+//	GET /users/:username/videos?cursor=&limit=20
+func (h *UserHandler) ListVideos(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.userService.ListVideosByUsername(c.Request.Context(), c.Param("username"), c.GetInt("user_id"), req)
+	if err != nil {
+		respondUserListError(c, err, "failed to list videos")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ListMyVideos returns all of the authenticated user's videos, including private ones.
+//
+//	GET /users/me/videos?cursor=&limit=20
+func (h *UserHandler) ListMyVideos(c *gin.Context) {
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.userService.ListVideos(c.Request.Context(), c.GetInt("user_id"), c.GetInt("user_id"), req)
+	if err != nil {
+		respondUserListError(c, err, "failed to list videos")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // UpdateProfile updates the authenticated user's display name, profile picture, and bio.
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	var req dto.UpdateProfileRequest
@@ -83,6 +150,96 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"user": user})
 }
 
+// SetUsername changes the authenticated user's username. Once set here,
+// /users/sync no longer updates it from the identity provider.
+//
+//	PATCH /users/me/username  {"username": "dkang"}
+func (h *UserHandler) SetUsername(c *gin.Context) {
+	var req dto.SetUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.SetUsername(c.Request.Context(), c.GetInt("user_id"), req.Username)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrInvalidUsername), errors.Is(err, apperr.ErrReservedUsername):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, apperr.ErrDuplicate):
+			c.JSON(http.StatusConflict, gin.H{"error": "username is taken"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to set username"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// UsernameAvailable checks whether a username can be claimed.
+//
+//	GET /users/username-available?username=dkang
+func (h *UserHandler) UsernameAvailable(c *gin.Context) {
+	username := c.Query("username")
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "username is required"})
+		return
+	}
+
+	resp := dto.UsernameAvailabilityResponse{Username: username}
+	available, err := h.userService.UsernameAvailable(c.Request.Context(), username)
+	switch {
+	case errors.Is(err, apperr.ErrInvalidUsername), errors.Is(err, apperr.ErrReservedUsername):
+		resp.Reason = err.Error()
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check username"})
+		return
+	case !available:
+		resp.Reason = "username is taken"
+	default:
+		resp.Available = true
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdatePrivacy updates the authenticated user's privacy settings.
+//
+//	PATCH /users/me/privacy  {"locationPrivacy": "approximate"}
+func (h *UserHandler) UpdatePrivacy(c *gin.Context) {
+	var req dto.UpdatePrivacyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.userService.UpdatePrivacy(c.Request.Context(), c.GetInt("user_id"), req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update privacy settings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user})
+}
+
+// DeleteMe deletes the authenticated user's account. The profile and videos are
+// hidden at once; everything else is purged after the grace period.
+//
+//	DELETE /users/me
+func (h *UserHandler) DeleteMe(c *gin.Context) {
+	if err := h.accountDeletionService.Delete(c.Request.Context(), c.GetInt("user_id")); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete account"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // Search returns users matching a query string.
 //
 //	GET /users/search?q=dkang&max_results=10
@@ -99,11 +256,36 @@ func (h *UserHandler) Search(c *gin.Context) {
 		req.MaxResults = dto.SearchMaxResultsMax
 	}
 
+	req.ViewerID = c.GetInt("user_id")
+
 	response, err := h.userService.Search(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "user search failed"})
+		respondSearchError(c, err, "user search failed")
 		return
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+func respondUserListError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	case errors.Is(err, apperr.ErrInvalidCursor):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func respondSyncError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperr.ErrAccountDeleted):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	case errors.Is(err, apperr.ErrEmailTaken):
+		// sign in to the existing account and link this identity with a link code
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
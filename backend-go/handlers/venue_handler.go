@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/services"
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	venueService *services.VenueService
+}
+
+func NewVenueHandler(venueService *services.VenueService) *VenueHandler {
+	return &VenueHandler{venueService: venueService}
+}
+
+// Get returns a single venue by ID.
+//
+//	GET /venues/:id
+func (h *VenueHandler) Get(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue id"})
+		return
+	}
+
+	venue, err := h.venueService.Get(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "venue not found"})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get venue"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"venue": venue})
+}
+
+// Search returns venues whose name or city matches a query string.
+//
+//	GET /venues/search?q=toronto&max_results=10
+func (h *VenueHandler) Search(c *gin.Context) {
+	var req dto.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.MaxResults <= 0 {
+		req.MaxResults = dto.SearchMaxResultsDefault
+	}
+	if req.MaxResults > dto.SearchMaxResultsMax {
+		req.MaxResults = dto.SearchMaxResultsMax
+	}
+
+	response, err := h.venueService.Search(c.Request.Context(), req)
+	if err != nil {
+		respondSearchError(c, err, "venue search failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Nearby returns venues within a radius of a point, closest first.
+//
+//	GET /venues/nearby?lat=43.6435&lng=-79.3791&radius_m=2000&max_results=10
+func (h *VenueHandler) Nearby(c *gin.Context) {
+	var req dto.NearbyVenuesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.RadiusMeters <= 0 {
+		req.RadiusMeters = dto.NearbyRadiusMetersDefault
+	}
+	if req.RadiusMeters > dto.NearbyRadiusMetersMax {
+		req.RadiusMeters = dto.NearbyRadiusMetersMax
+	}
+	if req.MaxResults <= 0 {
+		req.MaxResults = dto.SearchMaxResultsDefault
+	}
+	if req.MaxResults > dto.SearchMaxResultsMax {
+		req.MaxResults = dto.SearchMaxResultsMax
+	}
+
+	venues, err := h.venueService.Nearby(c.Request.Context(), *req.Lat, *req.Lng, req.RadiusMeters, req.MaxResults)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "nearby venue lookup failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NearbyVenuesResponse{Results: venues})
+}
+
+// ListConcerts returns the concerts held at a venue, most recent first.
+//
+//	GET /venues/:id/concerts?limit=20&cursor=...
+func (h *VenueHandler) ListConcerts(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid venue id"})
+		return
+	}
+
+	var req dto.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Limit = clampPageLimit(req.Limit)
+
+	response, err := h.venueService.ListConcerts(c.Request.Context(), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "venue not found"})
+		case errors.Is(err, apperr.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list venue concerts"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -1,21 +1,30 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"log"
 	"strconv"
+	"time"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/areeeeeeeb/reLive/backend-go/services"
 	"github.com/gin-gonic/gin"
 )
 
+// videoEventsHeartbeat keeps idle event streams from being closed by proxies.
+const videoEventsHeartbeat = 25 * time.Second
+
 type VideoHandler struct {
-	videoService *services.VideoService
+	videoService      *services.VideoService
+	reactionService   *services.ReactionService
+	videoEventService *services.VideoEventService
 }
 
-func NewVideoHandler(videoService *services.VideoService) *VideoHandler {
-	return &VideoHandler{videoService: videoService}
+func NewVideoHandler(videoService *services.VideoService, reactionService *services.ReactionService, videoEventService *services.VideoEventService) *VideoHandler {
+	return &VideoHandler{videoService: videoService, reactionService: reactionService, videoEventService: videoEventService}
 }
 
 // GET /videos
@@ -23,9 +32,204 @@ func (h *VideoHandler) List(c *gin.Context) {
 	c.JSON(501, gin.H{"error": "not implemented"})
 }
 
-// GET /videos/:id
+// GET /videos/:id?share=
+// Returns the video with its view and reaction counts, plus the caller's own reaction.
+// Unlisted videos open for anyone passing their share token.
 func (h *VideoHandler) Get(c *gin.Context) {
-	c.JSON(501, gin.H{"error": "not implemented"})
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	video, err := h.videoService.Get(c.Request.Context(), videoID, c.GetInt("user_id"), c.Query("share"))
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(404, gin.H{"error": "video not found"})
+		} else {
+			c.JSON(500, gin.H{"error": "failed to get video"})
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{"video": video})
+}
+
+// POST /videos/:id/views?share=
+// Counts a view. Repeat views by the same viewer within the dedupe window are ignored.
+func (h *VideoHandler) RecordView(c *gin.Context) {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	if err := h.videoService.RecordView(c.Request.Context(), videoID, c.GetInt("user_id"), c.Query("share"), c.ClientIP()); err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(404, gin.H{"error": "video not found"})
+		} else {
+			c.JSON(500, gin.H{"error": "failed to record view"})
+		}
+		return
+	}
+
+	c.Status(204)
+}
+
+// PATCH /videos/:id
+// Changes the caller's video visibility, caption or linked concert.
+func (h *VideoHandler) Update(c *gin.Context) {
+	var req dto.UpdateVideoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	video, err := h.videoService.Update(c.Request.Context(), videoID, c.GetInt("user_id"), req)
+	if err != nil {
+		respondVideoSettingsError(c, err, "video or concert not found", "failed to update video")
+		return
+	}
+
+	c.JSON(200, gin.H{"video": video})
+}
+
+// POST /videos/:id/share-token
+// Issues a new share token for the caller's unlisted video, revoking the previous one.
+func (h *VideoHandler) RotateShareToken(c *gin.Context) {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	token, err := h.videoService.RotateShareToken(c.Request.Context(), videoID, c.GetInt("user_id"))
+	if err != nil {
+		respondVideoSettingsError(c, err, "video not found", "failed to rotate share token")
+		return
+	}
+
+	c.JSON(200, gin.H{"share_token": token})
+}
+
+// DELETE /videos/:id/share-token
+// Revokes the caller's video share token; the video stays unlisted.
+func (h *VideoHandler) RevokeShareToken(c *gin.Context) {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	if err := h.videoService.RevokeShareToken(c.Request.Context(), videoID, c.GetInt("user_id")); err != nil {
+		respondVideoSettingsError(c, err, "video not found", "failed to revoke share token")
+		return
+	}
+
+	c.Status(204)
+}
+
+func respondVideoSettingsError(c *gin.Context, err error, notFound string, fallback string) {
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		c.JSON(404, gin.H{"error": notFound})
+	case errors.Is(err, apperr.ErrForbidden):
+		c.JSON(403, gin.H{"error": "video does not belong to user"})
+	case errors.Is(err, apperr.ErrNotUnlisted):
+		c.JSON(409, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": fallback})
+	}
+}
+
+// PUT /videos/:id/reaction?share=
+func (h *VideoHandler) React(c *gin.Context) {
+	var req dto.ReactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	reaction, err := h.reactionService.React(c.Request.Context(), c.GetInt("user_id"), videoID, c.Query("share"), req.Reaction)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			c.JSON(404, gin.H{"error": "video not found"})
+		} else {
+			c.JSON(500, gin.H{"error": "failed to react"})
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{"reaction": reaction})
+}
+
+// DELETE /videos/:id/reaction
+func (h *VideoHandler) Unreact(c *gin.Context) {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	if err := h.reactionService.Unreact(c.Request.Context(), c.GetInt("user_id"), videoID); err != nil {
+		c.JSON(500, gin.H{"error": "failed to remove reaction"})
+		return
+	}
+
+	c.Status(204)
+}
+
+// GET /videos/events
+// Server-Sent Events stream of status transitions for the caller's videos: each
+// "video_status" event carries {video_id, kind: upload|thumbnail|detection, status}.
+// There is no transcode step, so there are no transcode events.
+// Events sent while the client is disconnected are not replayed.
+func (h *VideoHandler) Events(c *gin.Context) {
+	userID := c.GetInt("user_id")
+	if userID == 0 {
+		c.JSON(401, gin.H{"error": "user not found"})
+		return
+	}
+
+	events, unsubscribe := h.videoEventService.Subscribe(userID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(videoEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("ready", gin.H{"user_id": userID})
+	// Stream only flushes after a step, and the first one waits for an event or heartbeat
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case e := <-events:
+			c.SSEvent("video_status", e)
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
 }
 
 // POST /videos/upload/init
@@ -92,6 +296,42 @@ func (h *VideoHandler) UploadConfirm(c *gin.Context) {
 	})
 }
 
+// POST /videos/:id/concert
+// Confirms a detected concert for the user's video and records their attendance.
+func (h *VideoHandler) ConfirmConcert(c *gin.Context) {
+	var req dto.ConcertConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetInt("user_id")
+	if userID == 0 {
+		c.JSON(401, gin.H{"error": "user not found"})
+		return
+	}
+
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, gin.H{"error": "invalid video ID"})
+		return
+	}
+
+	if err := h.videoService.ConfirmConcert(c.Request.Context(), videoID, userID, req.ConcertID); err != nil {
+		switch {
+		case errors.Is(err, apperr.ErrNotFound):
+			c.JSON(404, gin.H{"error": "video or concert not found"})
+		case errors.Is(err, apperr.ErrForbidden):
+			c.JSON(403, gin.H{"error": "video does not belong to user"})
+		default:
+			c.JSON(500, gin.H{"error": "failed to confirm concert"})
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{"videoId": videoID, "concertId": req.ConcertID})
+}
+
 // DELETE /videos/:id
 func (h *VideoHandler) Delete(c *gin.Context) {
 	c.JSON(501, gin.H{"error": "not implemented"})
@@ -2,20 +2,32 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/config"
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/handlers"
 	"github.com/areeeeeeeb/reLive/backend-go/middleware"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
 	"github.com/areeeeeeeb/reLive/backend-go/services"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish on SIGINT/SIGTERM.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	// Create Gin router
-	ctx := context.Background()
+	// cancelled on SIGINT/SIGTERM; background loops stop and the server drains
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	// Load configuration from environment variables
 	cfg := config.Load()
 
@@ -23,12 +35,13 @@ func main() {
 		log.Fatalf("Invalid configuration: %v", err)
 	}
 
-	var authMiddleware gin.HandlerFunc
+	var authenticator middleware.Authenticator
 	if cfg.DevBypassAuth {
-		authMiddleware = middleware.DevAuthBypass(cfg.DevAuth0ID)
+		authenticator = middleware.NewDevAuthenticator(cfg.DevAuth0ID)
 	} else {
-		authMiddleware = middleware.AuthRequired(cfg.Auth0)
+		authenticator = middleware.NewAuth0Authenticator(cfg.Auth0)
 	}
+	authMiddleware := middleware.AuthRequired(authenticator)
 
 	pool, err := cfg.NewDBPool(ctx)
 	if err != nil {
@@ -42,6 +55,10 @@ func main() {
 	}
 	// Create Gin router
 	r := gin.Default()
+	// ClientIP (rate limit keys) only honors X-Forwarded-For from these; nil trusts none
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// CORS middleware
 	r.Use(cors.New(cors.Config{
@@ -58,39 +75,100 @@ func main() {
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: true,
 	}))
 
 	// store for DB operations
 	store := database.NewStore(pool, cfg.Store.SearchTrgmSimilarityThreshold)
-	searchService := services.NewSearchService()
+	// viewerAuth identifies callers that send a token and lets anonymous ones through
+	viewerAuth := middleware.OptionalAuth(authenticator, store)
+	// tokenAuth also accepts personal access tokens; pair it with RequireScope
+	tokenAuth := middleware.AuthRequired(middleware.ChainAuthenticators(middleware.NewPersonalTokenAuthenticator(store), authenticator))
+	// rate limits, keyed by user ID after auth (client IP for anonymous callers)
+	rateLimiter := middleware.NewRateLimiter(cfg.RateLimit, store)
+	rateLimiter.Start(ctx)
+	uploadLimit := middleware.RateLimit(rateLimiter, "upload", cfg.RateLimit.Upload)
+	searchLimit := middleware.RateLimit(rateLimiter, "search", cfg.RateLimit.Search)
+	cursorKey := []byte(cfg.CursorSigningKey)
+	if len(cursorKey) == 0 {
+		// development only (Validate requires a key elsewhere): cursors won't survive a restart
+		cursorKey = make([]byte, 32)
+		if _, err := rand.Read(cursorKey); err != nil {
+			log.Fatalf("Failed to generate cursor signing key: %v", err)
+		}
+		log.Println("CURSOR_SIGNING_KEY not set, using a random per-process key")
+	}
+	searchService := services.NewSearchService(cursorKey)
 
 	// add service structs here
-	userService := services.NewUserService(store, searchService)
+	notificationService := services.NewNotificationService(store, searchService)
+	reactionService := services.NewReactionService(store)
+	viewCounter := services.NewViewCounter(store, cfg.Views.DedupeWindow, cfg.Views.FlushInterval)
+	// views outlive ctx so requests still draining at shutdown are counted before the final flush
+	viewCtx, stopViews := context.WithCancel(context.Background())
+	viewCounter.Start(viewCtx)
+	videoEventService := services.NewVideoEventService(store)
+	videoEventService.Start(ctx)
 	actService := services.NewActService(store)
 	songPerformanceService := services.NewSongPerformanceService(store)
 	uploadService := services.NewUploadService(s3Client, cfg.Spaces.Bucket, cfg.Spaces.CdnURL)
+	playbackService := services.NewPlaybackService(uploadService, cfg.Spaces.PlaybackURLTTL)
+	locationPrivacyService := services.NewLocationPrivacyService(store)
+	userService := services.NewUserService(store, searchService, reactionService, playbackService, locationPrivacyService)
 	concertService := services.NewConcertService(store, searchService)
-	artistService := services.NewArtistService(store, searchService)
-	songService := services.NewSongService(store, searchService)
-	detectionService := services.NewDetectionService(store)
+	artistService := services.NewArtistService(store, searchService, concertService, reactionService, locationPrivacyService)
+	songService := services.NewSongService(store, searchService, concertService)
+	venueService := services.NewVenueService(store, searchService, concertService)
+	detectionService := services.NewDetectionService(store, notificationService)
+	unifiedSearchService := services.NewUnifiedSearchService(artistService, songService, concertService, userService, cfg.Search.UnifiedMaxConcurrentQueries, cfg.Search.UnifiedTimeout)
 
 	mediaService, err := services.NewMediaService()
 	if err != nil {
 		log.Fatalf("Failed to initialize media service: %v", err)
 	}
 	thumbnailService := services.NewThumbnailService(store, mediaService, uploadService)
-	jobQueue := services.NewJobQueueService(store, thumbnailService, cfg.Concurrency.Concurrency, cfg.Concurrency.QueueSize, cfg.Concurrency.SchedulerInterval, cfg.Concurrency.StuckThreshold, cfg.Concurrency.ResetInterval)
+	var locationStripService *services.LocationStripService
+	if cfg.Spaces.StripVideoLocation {
+		locationStripService = services.NewLocationStripService(store, mediaService, uploadService)
+	}
+	dataExportService := services.NewDataExportService(store, uploadService, notificationService, cfg.Accounts.DataExportTTL)
+	jobQueue := services.NewJobQueueService(store, thumbnailService, locationStripService, dataExportService, notificationService, cfg.Concurrency.Concurrency, cfg.Concurrency.QueueSize, cfg.Concurrency.SchedulerInterval, cfg.Concurrency.StuckThreshold, cfg.Concurrency.ResetInterval)
 	jobQueue.Start(ctx)
-	videoService := services.NewVideoService(store, uploadService)
+	songStatsService := services.NewSongStatsService(store, cfg.Concurrency.StatsRefreshInterval)
+	songStatsService.Start(ctx)
+	attendanceService := services.NewAttendanceService(store, searchService, concertService)
+	videoService := services.NewVideoService(store, uploadService, attendanceService, reactionService, playbackService, locationPrivacyService, viewCounter)
+	followService := services.NewFollowService(store, searchService, notificationService)
+	commentService := services.NewCommentService(store, searchService, notificationService)
+	feedService := services.NewFeedService(store, searchService, concertService, reactionService, locationPrivacyService)
+	adminService := services.NewAdminService(store, searchService)
+	accountDeletionService := services.NewAccountDeletionService(store, uploadService, playbackService, cfg.Accounts.DeletionGracePeriod, cfg.Accounts.PurgeInterval)
+	accountDeletionService.Start(ctx)
+	var auth0UserInfo *services.Auth0UserInfo
+	if !cfg.DevBypassAuth {
+		auth0UserInfo = services.NewAuth0UserInfo(cfg.Auth0.Domain)
+	}
+	identityService := services.NewIdentityService(store, auth0UserInfo)
+	personalTokenService := services.NewPersonalTokenService(store)
 
 	// add handler structs here
-	userHandler := handlers.NewUserHandler(userService)
+	userHandler := handlers.NewUserHandler(userService, identityService, accountDeletionService)
 	concertHandler := handlers.NewConcertHandler(concertService, actService, songPerformanceService, videoService, detectionService)
-	videoHandler := handlers.NewVideoHandler(videoService)
+	videoHandler := handlers.NewVideoHandler(videoService, reactionService, videoEventService)
 	artistHandler := handlers.NewArtistHandler(artistService)
 	songHandler := handlers.NewSongHandler(songService)
+	venueHandler := handlers.NewVenueHandler(venueService)
+	searchHandler := handlers.NewSearchHandler(unifiedSearchService)
+	attendanceHandler := handlers.NewAttendanceHandler(attendanceService)
+	followHandler := handlers.NewFollowHandler(followService)
+	feedHandler := handlers.NewFeedHandler(feedService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	adminHandler := handlers.NewAdminHandler(adminService, accountDeletionService)
+	dataExportHandler := handlers.NewDataExportHandler(dataExportService)
+	identityHandler := handlers.NewIdentityHandler(identityService)
+	personalTokenHandler := handlers.NewPersonalTokenHandler(personalTokenService)
 
 	// Basic health check endpoint
 	r.GET("/health", func(c *gin.Context) {
@@ -119,22 +197,63 @@ func main() {
 			})
 		})
 
+		// unified search across artists, songs, concerts and users
+		v2.GET("/search", viewerAuth, searchLimit, searchHandler.Search)
+
 		// users routes
 		users := v2.Group("/users")
 		{
-			users.GET("/search", userHandler.Search)
+			users.GET("/username-available", userHandler.UsernameAvailable)
+			users.GET("/:username/concerts", attendanceHandler.ListUserConcerts)
+			users.GET("/:username/followers", followHandler.Followers)
+			users.GET("/:username/following", followHandler.Following)
+
+			// public routes that personalize for a signed-in viewer
+			usersViewer := users.Group("")
+			usersViewer.Use(viewerAuth)
+			{
+				usersViewer.GET("/search", searchLimit, userHandler.Search)
+				usersViewer.GET("/:username", userHandler.Profile)
+				usersViewer.GET("/:username/videos", userHandler.ListVideos)
+			}
 
 			usersAuth := users.Group("")
 			usersAuth.Use(authMiddleware)
 			{
 				usersAuth.POST("/sync", userHandler.Sync)
+				usersAuth.POST("/link", identityHandler.Link)
+			}
+
+			// routes personal access tokens with the read scope can reach
+			usersToken := users.Group("")
+			usersToken.Use(tokenAuth, middleware.ResolveUser(store), middleware.RequireScope(models.TokenScopeRead))
+			{
+				usersToken.GET("/me", userHandler.Me)
+				usersToken.GET("/me/videos", userHandler.ListMyVideos)
+				usersToken.GET("/me/concerts", attendanceHandler.ListMyConcerts)
 			}
 
 			usersResolved := users.Group("")
 			usersResolved.Use(authMiddleware, middleware.ResolveUser(store))
 			{
-				usersResolved.GET("/me", userHandler.Me)
 				usersResolved.PATCH("/me", userHandler.UpdateProfile)
+				usersResolved.DELETE("/me", userHandler.DeleteMe)
+				usersResolved.PATCH("/me/username", userHandler.SetUsername)
+				usersResolved.GET("/me/identities", identityHandler.List)
+				usersResolved.DELETE("/me/identities/:subject", identityHandler.Unlink)
+				usersResolved.POST("/me/link-code", identityHandler.CreateLinkCode)
+				usersResolved.POST("/me/tokens", personalTokenHandler.Create)
+				usersResolved.GET("/me/tokens", personalTokenHandler.List)
+				usersResolved.DELETE("/me/tokens/:id", personalTokenHandler.Revoke)
+				usersResolved.POST("/me/exports", dataExportHandler.Request)
+				usersResolved.GET("/me/exports", dataExportHandler.List)
+				usersResolved.GET("/me/exports/:id", dataExportHandler.Get)
+				usersResolved.PATCH("/me/privacy", userHandler.UpdatePrivacy)
+				usersResolved.GET("/me/feed", feedHandler.Home)
+				usersResolved.POST("/:username/follow", followHandler.FollowUser)
+				usersResolved.DELETE("/:username/follow", followHandler.UnfollowUser)
+				usersResolved.POST("/:username/block", followHandler.BlockUser)
+				usersResolved.DELETE("/:username/block", followHandler.UnblockUser)
 			}
 		}
 
@@ -142,47 +261,167 @@ func main() {
 		videos := v2.Group("/videos")
 		{
 			videos.GET("", videoHandler.List)
-			videos.GET("/:id", videoHandler.Get)
+
+			videosViewer := videos.Group("")
+			videosViewer.Use(viewerAuth)
+			{
+				videosViewer.GET("/:id", videoHandler.Get)
+				videosViewer.POST("/:id/views", videoHandler.RecordView)
+				videosViewer.GET("/:id/comments", commentHandler.ListVideoComments)
+			}
+
+			// upload routes, also open to personal access tokens with the videos:write scope
+			videosToken := videos.Group("")
+			videosToken.Use(tokenAuth, middleware.ResolveUser(store), middleware.RequireScope(models.TokenScopeVideosWrite))
+			{
+				videosToken.POST("/upload/init", uploadLimit, videoHandler.UploadInit)
+				videosToken.POST("/:id/upload/confirm", videoHandler.UploadConfirm)
+				videosToken.POST("/:id/concert", videoHandler.ConfirmConcert)
+				videosToken.PATCH("/:id", videoHandler.Update)
+			}
 
 			videosResolved := videos.Group("")
 			videosResolved.Use(authMiddleware, middleware.ResolveUser(store))
 			{
-				videosResolved.POST("/upload/init", videoHandler.UploadInit)
-				videosResolved.POST("/:id/upload/confirm", videoHandler.UploadConfirm)
+				videosResolved.GET("/events", videoHandler.Events)
 				videosResolved.DELETE("/:id", videoHandler.Delete)
+				videosResolved.POST("/:id/share-token", videoHandler.RotateShareToken)
+				videosResolved.DELETE("/:id/share-token", videoHandler.RevokeShareToken)
+				videosResolved.PUT("/:id/reaction", videoHandler.React)
+				videosResolved.DELETE("/:id/reaction", videoHandler.Unreact)
+				videosResolved.POST("/:id/comments", commentHandler.CreateVideoComment)
 			}
 		}
 
 		// artists routes
 		artists := v2.Group("/artists")
 		{
-			artists.GET("/search", artistHandler.Search)
-			artists.GET("/:id", artistHandler.Get)
+			artists.GET("/:id/concerts", artistHandler.ListConcerts)
+			artists.GET("/:id/songs", artistHandler.ListSongs)
+			artists.GET("/:id/followers", followHandler.ArtistFollowers)
+
+			// public routes that personalize for a signed-in viewer
+			artistsViewer := artists.Group("")
+			artistsViewer.Use(viewerAuth)
+			{
+				artistsViewer.GET("/search", searchLimit, artistHandler.Search)
+				artistsViewer.GET("/:id", artistHandler.Get)
+				artistsViewer.GET("/:id/videos", artistHandler.ListVideos)
+			}
+
+			artistsResolved := artists.Group("")
+			artistsResolved.Use(authMiddleware, middleware.ResolveUser(store))
+			{
+				artistsResolved.POST("/:id/follow", followHandler.FollowArtist)
+				artistsResolved.DELETE("/:id/follow", followHandler.UnfollowArtist)
+			}
 		}
 
 		// songs routes
 		songs := v2.Group("/songs")
 		{
-			songs.GET("/search", songHandler.Search)
+			songs.GET("/search", searchLimit, songHandler.Search)
 			songs.GET("/:id", songHandler.Get)
+			songs.GET("/:id/performances", songHandler.ListPerformances)
+			songs.GET("/:id/stats", songHandler.Stats)
+		}
+
+		// venues routes
+		venues := v2.Group("/venues")
+		{
+			venues.GET("/search", searchLimit, venueHandler.Search)
+			venues.GET("/nearby", venueHandler.Nearby)
+			venues.GET("/:id", venueHandler.Get)
+			venues.GET("/:id/concerts", venueHandler.ListConcerts)
 		}
 
 		concerts := v2.Group("/concerts")
 		{
-			concerts.GET("/search", concertHandler.Search)
+			concerts.GET("/search", searchLimit, concertHandler.Search)
+			concerts.GET("/calendar", concertHandler.Calendar)
 			concerts.GET("/:id", concertHandler.Get)
-			concerts.GET("/:id/videos", concertHandler.ListVideos)
+			concerts.GET("/:id/videos", viewerAuth, concertHandler.ListVideos)
 			concerts.GET("/:id/acts", concertHandler.ListActs)
 			concerts.GET("/:id/song-performances", concertHandler.ListSongPerformances)
+			concerts.GET("/:id/attendees", viewerAuth, attendanceHandler.ListAttendees)
+			concerts.GET("/:id/comments", viewerAuth, commentHandler.ListConcertComments)
+
+			// concert detection is part of the upload flow
+			concerts.POST("/detect", tokenAuth, middleware.ResolveUser(store), middleware.RequireScope(models.TokenScopeVideosWrite), concertHandler.Detect)
 
 			concertsResolved := concerts.Group("")
 			concertsResolved.Use(authMiddleware, middleware.ResolveUser(store))
 			{
-				concertsResolved.POST("/detect", concertHandler.Detect)
+				concertsResolved.GET("/calendar/mine", concertHandler.Calendar)
+				concertsResolved.POST("/:id/attendance", attendanceHandler.Mark)
+				concertsResolved.DELETE("/:id/attendance", attendanceHandler.Unmark)
+				concertsResolved.POST("/:id/comments", commentHandler.CreateConcertComment)
+			}
+		}
+
+		// comments routes (comments are created under their video or concert)
+		comments := v2.Group("/comments")
+		{
+			comments.GET("/:id/replies", viewerAuth, commentHandler.ListReplies)
+
+			commentsResolved := comments.Group("")
+			commentsResolved.Use(authMiddleware, middleware.ResolveUser(store))
+			{
+				commentsResolved.PATCH("/:id", commentHandler.Update)
+				commentsResolved.DELETE("/:id", commentHandler.Delete)
+				commentsResolved.POST("/:id/report", commentHandler.Report)
+			}
+		}
+
+		// notifications routes
+		notifications := v2.Group("/notifications")
+		notifications.Use(authMiddleware, middleware.ResolveUser(store))
+		{
+			notifications.GET("", notificationHandler.List)
+			notifications.GET("/unread-count", notificationHandler.UnreadCount)
+			notifications.POST("/read-all", notificationHandler.MarkAllRead)
+			notifications.POST("/:id/read", notificationHandler.MarkRead)
+			notifications.GET("/preferences", notificationHandler.GetPreferences)
+			notifications.PUT("/preferences", notificationHandler.UpdatePreferences)
+		}
+
+		// admin routes. Account-level tooling (e.g. merges) belongs in the admin-only group.
+		admin := v2.Group("/admin")
+		admin.Use(authMiddleware, middleware.ResolveUser(store))
+		{
+			moderation := admin.Group("")
+			moderation.Use(middleware.RequireRole(models.RoleModerator))
+			{
+				moderation.GET("/comment-reports", adminHandler.ListCommentReports)
+				moderation.POST("/comment-reports/:id/resolve", adminHandler.ResolveCommentReport)
+			}
+
+			adminOnly := admin.Group("")
+			adminOnly.Use(middleware.RequireRole(models.RoleAdmin))
+			{
+				adminOnly.PUT("/users/:username/role", adminHandler.SetRole)
+				adminOnly.GET("/users/:username/role-changes", adminHandler.ListRoleChanges)
+				adminOnly.POST("/users/:username/merge", adminHandler.MergeUsers)
+				adminOnly.POST("/users/:username/restore", adminHandler.RestoreUser)
 			}
 		}
 	}
 
 	// Start server on port 8081 (TypeScript backend is on 8080)
-	r.Run(":8081")
+	srv := &http.Server{Addr: ":8081", Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown: %v", err)
+	}
+	stopViews()
+	viewCounter.Wait()
 }
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"net/url"
@@ -18,7 +20,57 @@ const (
 	clockSkewLimit = time.Minute      // allowed clock drift when validating exp/nbf/iat
 )
 
-func AuthRequired(auth0 config.Auth0Config) gin.HandlerFunc {
+// auth0Claims are the non-registered claims we read from Auth0 access tokens.
+// permissions is only present when RBAC is enabled for the API in Auth0.
+type auth0Claims struct {
+	Permissions []string `json:"permissions"`
+}
+
+func (c *auth0Claims) Validate(ctx context.Context) error {
+	return nil
+}
+
+// Authenticator checks a request's credentials and records the caller on the
+// context as auth0_id (plus auth0_permissions when the token carries them).
+// It returns errNoCredentials when the request carries no credentials at all.
+type Authenticator func(c *gin.Context) error
+
+var (
+	errNoCredentials   = errors.New("missing Authorization header")
+	errNotBearer       = errors.New("Authorization header must use the Bearer scheme")
+	errMissingToken    = errors.New("missing bearer token")
+	errInvalidToken    = errors.New("invalid token")
+	errInvalidClaims   = errors.New("invalid token claims")
+	errMissingSubClaim = errors.New("token missing sub")
+)
+
+// AuthRequired rejects requests that don't authenticate with 401.
+func AuthRequired(authenticate Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := authenticate(c); err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// ChainAuthenticators tries each authenticator in turn and uses the first one that
+// recognizes the request's credentials. Authenticators that don't (e.g. a personal
+// token authenticator seeing a JWT) return errNoCredentials to pass the request on.
+func ChainAuthenticators(authenticators ...Authenticator) Authenticator {
+	return func(c *gin.Context) error {
+		for _, authenticate := range authenticators {
+			if err := authenticate(c); !errors.Is(err, errNoCredentials) {
+				return err
+			}
+		}
+		return errNoCredentials
+	}
+}
+
+// NewAuth0Authenticator validates Auth0-issued Bearer JWTs.
+func NewAuth0Authenticator(auth0 config.Auth0Config) Authenticator {
 	domain := auth0.Domain
 	audience := auth0.Audience
 
@@ -35,45 +87,46 @@ func AuthRequired(auth0 config.Auth0Config) gin.HandlerFunc {
 		issuerUrl.String(), // expected `iss` (issuer) claim; must match Auth0 domain URL
 		[]string{audience}, // expected `aud` (audience) claim; must include your API identifier
 		validator.WithAllowedClockSkew(clockSkewLimit), // allow small clock differences when checking exp/nbf/iat
+		validator.WithCustomClaims(func() validator.CustomClaims { return &auth0Claims{} }), // pick up the RBAC permissions claim
 	)
 	if err != nil {
 		log.Fatalf("Failed to set up the jwt validator: %v", err)
 	}
 
-	return func(c *gin.Context) {
+	return func(c *gin.Context) error {
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid Authorization header"})
-			return
+		if authHeader == "" {
+			return errNoCredentials
+		}
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			return errNotBearer
 		}
 
 		rawToken := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
 		if rawToken == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
-			return
+			return errMissingToken
 		}
 
 		claimsAny, err := jwtValidator.ValidateToken(c.Request.Context(), rawToken)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			return
+			return errInvalidToken
 		}
 		// now we can safely assume claims is *validator.ValidatedClaims
 		claims, ok := claimsAny.(*validator.ValidatedClaims)
 		if !ok || claims == nil {
 			// we really shouldn't get here
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
-			return
+			return errInvalidClaims
 		}
 
 		auth0ID := claims.RegisteredClaims.Subject
 		if auth0ID == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token missing sub"})
-			return
+			return errMissingSubClaim
 		}
 
 		c.Set("auth0_id", auth0ID)
-
-		c.Next()
+		if custom, ok := claims.CustomClaims.(*auth0Claims); ok && custom != nil {
+			c.Set("auth0_permissions", custom.Permissions)
+		}
+		return nil
 	}
 }
@@ -6,11 +6,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// DevAuthBypass injects auth0_id in local development so protected routes can
-// be hit without obtaining a real JWT.
-func DevAuthBypass(defaultAuth0ID string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Set("auth0_id", strings.TrimSpace(defaultAuth0ID))
-		c.Next()
+// NewDevAuthenticator authenticates every request as auth0ID in local development
+// so protected routes can be hit without obtaining a real JWT.
+func NewDevAuthenticator(auth0ID string) Authenticator {
+	auth0ID = strings.TrimSpace(auth0ID)
+	return func(c *gin.Context) error {
+		c.Set("auth0_id", auth0ID)
+		return nil
 	}
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/gin-gonic/gin"
+)
+
+// OptionalAuth is AuthRequired + ResolveUser for public routes that personalize for
+// a signed-in viewer. Requests without credentials continue anonymously; a request
+// that does send a token still gets it validated (and rejected if it is bad).
+//
+// Handlers see the same viewer context either way: user_id and role are set for a
+// known caller, and user_id reads as 0 for anonymous ones (including callers whose
+// token is valid but who haven't synced a user yet).
+func OptionalAuth(authenticate Authenticator, store *database.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := authenticate(c); err != nil {
+			if errors.Is(err, errNoCredentials) {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		resolveViewer(c, store)
+		c.Next()
+	}
+}
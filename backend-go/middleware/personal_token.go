@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidPersonalToken = errors.New("invalid or expired personal access token")
+	errPersonalTokenLookup  = errors.New("could not check personal access token")
+)
+
+// NewPersonalTokenAuthenticator accepts personal access tokens (Bearer rlv_pat_…) and
+// sets the same auth0_id as the owner's own sign-in would, plus token_scopes. Any
+// other credentials are left to the next authenticator in a chain.
+func NewPersonalTokenAuthenticator(store *database.Store) Authenticator {
+	return func(c *gin.Context) error {
+		rawToken, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		rawToken = strings.TrimSpace(rawToken)
+		if !ok || !strings.HasPrefix(rawToken, models.PersonalAccessTokenPrefix) {
+			return errNoCredentials
+		}
+
+		token, auth0ID, err := store.UsePersonalAccessToken(c.Request.Context(), models.HashPersonalAccessToken(rawToken))
+		if err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				return errInvalidPersonalToken
+			}
+			return errPersonalTokenLookup
+		}
+
+		c.Set("auth0_id", auth0ID)
+		c.Set("token_scopes", token.Scopes)
+		return nil
+	}
+}
+
+// RequireScope only lets personal access tokens through if they carry scope.
+// Callers signed in with Auth0 aren't limited by scopes and always pass.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, isToken := c.Get("token_scopes")
+		if isToken {
+			granted, _ := scopes.([]string)
+			if !slices.Contains(granted, scope) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "token is missing scope " + scope})
+				return
+			}
+		}
+		c.Next()
+	}
+}
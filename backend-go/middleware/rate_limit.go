@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/config"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/gin-gonic/gin"
+)
+
+const rateLimitPruneInterval = time.Minute // how often refilled buckets are dropped
+
+// RateLimiter keeps token buckets holding burst tokens that refill one per interval.
+// Take spends a token from key's bucket if one is available, and reports how long
+// until the bucket is full again either way. Start runs the limiter's housekeeping.
+type RateLimiter interface {
+	Take(ctx context.Context, key string, interval time.Duration, burst int) (allowed bool, fullIn time.Duration, err error)
+	Start(ctx context.Context)
+}
+
+// NewRateLimiter returns the limiter backend named by cfg.Backend.
+func NewRateLimiter(cfg config.RateLimitConfig, store *database.Store) RateLimiter {
+	if cfg.Backend == config.RateLimitBackendPostgres {
+		return NewPostgresRateLimiter(store)
+	}
+	return NewMemoryRateLimiter()
+}
+
+// RateLimit throttles a route group with one token bucket per signed-in user, or per
+// client IP for anonymous callers, so it must run after the group's auth middleware.
+// Every response carries RateLimit-Limit/-Remaining/-Reset; rejected requests get 429
+// with Retry-After. name keeps each group's buckets apart. A zero PerMinute disables it.
+//
+// If the limiter itself fails the request is let through: an outage of the limiter's
+// storage shouldn't take the API down with it.
+func RateLimit(limiter RateLimiter, name string, limit config.RateLimit) gin.HandlerFunc {
+	if limit.PerMinute <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	interval := time.Minute / time.Duration(limit.PerMinute)
+	policy := fmt.Sprintf("%d;w=%d", limit.Burst, ceilSeconds(interval*time.Duration(limit.Burst)))
+
+	return func(c *gin.Context) {
+		key := name + ":ip:" + c.ClientIP()
+		if userID := c.GetInt("user_id"); userID != 0 {
+			key = name + ":user:" + strconv.Itoa(userID)
+		}
+
+		allowed, fullIn, err := limiter.Take(c.Request.Context(), key, interval, limit.Burst)
+		if err != nil {
+			log.Printf("[rate-limit] %s: %v", key, err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Policy", policy)
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(rateLimitRemaining(fullIn, interval, limit.Burst)))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(fullIn)))
+
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(rateLimitRetryAfter(fullIn, interval, limit.Burst)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitRemaining is how many tokens a bucket that is full in fullIn still holds.
+func rateLimitRemaining(fullIn, interval time.Duration, burst int) int {
+	remaining := burst - int(math.Ceil(float64(fullIn)/float64(interval)))
+	return max(remaining, 0)
+}
+
+// rateLimitRetryAfter is the whole seconds, at least 1, until an empty bucket that is
+// full in fullIn gets its next token: once it is within burst-1 intervals of full.
+func rateLimitRetryAfter(fullIn, interval time.Duration, burst int) int {
+	return max(ceilSeconds(fullIn-time.Duration(burst-1)*interval), 1)
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// MemoryRateLimiter keeps buckets in process memory, so each replica enforces its own limits.
+// Like the Postgres limiter, a bucket is just the time it will be full again.
+type MemoryRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]time.Time
+	now     func() time.Time // swapped out by tests
+}
+
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{buckets: make(map[string]time.Time), now: time.Now}
+}
+
+func (l *MemoryRateLimiter) Take(ctx context.Context, key string, interval time.Duration, burst int) (bool, time.Duration, error) {
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fullAt := l.buckets[key]
+	if fullAt.Before(now) {
+		fullAt = now
+	}
+	if fullAt.Sub(now) > time.Duration(burst-1)*interval {
+		return false, fullAt.Sub(now), nil
+	}
+	fullAt = fullAt.Add(interval)
+	l.buckets[key] = fullAt
+	return true, fullAt.Sub(now), nil
+}
+
+// Start drops refilled buckets in the background until ctx is cancelled.
+func (l *MemoryRateLimiter) Start(ctx context.Context) {
+	go runPruneLoop(ctx, func(ctx context.Context) error {
+		now := l.now()
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for key, fullAt := range l.buckets {
+			if !fullAt.After(now) {
+				delete(l.buckets, key)
+			}
+		}
+		return nil
+	})
+	log.Println("[rate-limit] started (memory)")
+}
+
+// PostgresRateLimiter keeps buckets in the rate_limit_buckets table, so limits hold
+// across replicas. Each request costs one round trip (two when it is rejected).
+type PostgresRateLimiter struct {
+	store *database.Store
+}
+
+func NewPostgresRateLimiter(store *database.Store) *PostgresRateLimiter {
+	return &PostgresRateLimiter{store: store}
+}
+
+func (l *PostgresRateLimiter) Take(ctx context.Context, key string, interval time.Duration, burst int) (bool, time.Duration, error) {
+	return l.store.TakeRateLimitToken(ctx, key, interval, burst)
+}
+
+// Start deletes refilled buckets in the background until ctx is cancelled.
+func (l *PostgresRateLimiter) Start(ctx context.Context) {
+	go runPruneLoop(ctx, l.store.DeleteFullRateLimitBuckets)
+	log.Println("[rate-limit] started (postgres)")
+}
+
+func runPruneLoop(ctx context.Context, prune func(ctx context.Context) error) {
+	ticker := time.NewTicker(rateLimitPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := prune(ctx); err != nil {
+				log.Printf("[rate-limit] failed to prune buckets: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimiterTake(t *testing.T) {
+	const interval = 10 * time.Second
+	const burst = 3
+
+	type step struct {
+		advance     time.Duration // clock moves forward before the Take
+		key         string
+		wantAllowed bool
+		wantFullIn  time.Duration
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"first take from a full bucket", []step{
+			{0, "a", true, 10 * time.Second},
+		}},
+		{"burst then rejected", []step{
+			{0, "a", true, 10 * time.Second},
+			{0, "a", true, 20 * time.Second},
+			{0, "a", true, 30 * time.Second},
+			{0, "a", false, 30 * time.Second},
+			{0, "a", false, 30 * time.Second},
+		}},
+		{"one token refills after an interval", []step{
+			{0, "a", true, 10 * time.Second},
+			{0, "a", true, 20 * time.Second},
+			{0, "a", true, 30 * time.Second},
+			{9 * time.Second, "a", false, 21 * time.Second},
+			{time.Second, "a", true, 30 * time.Second},
+		}},
+		{"idle bucket refills to burst, not past it", []step{
+			{0, "a", true, 10 * time.Second},
+			{time.Hour, "a", true, 10 * time.Second},
+			{0, "a", true, 20 * time.Second},
+			{0, "a", true, 30 * time.Second},
+			{0, "a", false, 30 * time.Second},
+		}},
+		{"keys have separate buckets", []step{
+			{0, "a", true, 10 * time.Second},
+			{0, "a", true, 20 * time.Second},
+			{0, "a", true, 30 * time.Second},
+			{0, "b", true, 10 * time.Second},
+			{0, "a", false, 30 * time.Second},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			l := NewMemoryRateLimiter()
+			l.now = func() time.Time { return now }
+
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				allowed, fullIn, err := l.Take(context.Background(), s.key, interval, burst)
+				if err != nil {
+					t.Fatalf("step %d: unexpected error: %v", i, err)
+				}
+				if allowed != s.wantAllowed || fullIn != s.wantFullIn {
+					t.Errorf("step %d: Take(%q) = (%v, %v), want (%v, %v)", i, s.key, allowed, fullIn, s.wantAllowed, s.wantFullIn)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitRemaining(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullIn   time.Duration
+		interval time.Duration
+		burst    int
+		want     int
+	}{
+		{"full bucket", 0, 10 * time.Second, 5, 5},
+		{"one token spent", 10 * time.Second, 10 * time.Second, 5, 4},
+		{"partially refilled token counts as spent", 15 * time.Second, 10 * time.Second, 5, 3},
+		{"just under a whole token", 19999 * time.Millisecond, 10 * time.Second, 5, 3},
+		{"empty bucket", 50 * time.Second, 10 * time.Second, 5, 0},
+		{"never negative", 70 * time.Second, 10 * time.Second, 5, 0},
+		{"burst of one", 6 * time.Second, 6 * time.Second, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rateLimitRemaining(tt.fullIn, tt.interval, tt.burst); got != tt.want {
+				t.Errorf("rateLimitRemaining(%v, %v, %d) = %d, want %d", tt.fullIn, tt.interval, tt.burst, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullIn   time.Duration
+		interval time.Duration
+		burst    int
+		want     int
+	}{
+		{"whole interval until the next token", 50 * time.Second, 10 * time.Second, 5, 10},
+		{"partial interval rounds up", 41500 * time.Millisecond, 10 * time.Second, 5, 2},
+		{"next token due now still waits a second", 40 * time.Second, 10 * time.Second, 5, 1},
+		{"sub-second wait rounds up to a second", 40100 * time.Millisecond, 10 * time.Second, 5, 1},
+		{"burst of one waits for the whole bucket", 6 * time.Second, 6 * time.Second, 1, 6},
+		{"one per hour", time.Hour, time.Hour, 1, 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rateLimitRetryAfter(tt.fullIn, tt.interval, tt.burst); got != tt.want {
+				t.Errorf("rateLimitRetryAfter(%v, %v, %d) = %d, want %d", tt.fullIn, tt.interval, tt.burst, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth0 permissions that grant a role for the lifetime of a token, on top of users.role.
+const (
+	PermissionRoleModerator = "role:moderator"
+	PermissionRoleAdmin     = "role:admin"
+)
+
+// effectiveRole is the higher of the user's stored role and any role granted by the
+// token's Auth0 permissions claim.
+func effectiveRole(c *gin.Context, storedRole string) string {
+	role := storedRole
+	permissions := c.GetStringSlice("auth0_permissions")
+	if slices.Contains(permissions, PermissionRoleModerator) {
+		role = models.HigherRole(role, models.RoleModerator)
+	}
+	if slices.Contains(permissions, PermissionRoleAdmin) {
+		role = models.HigherRole(role, models.RoleAdmin)
+	}
+	return role
+}
+
+// RequireRole only lets through callers whose role is at least min (see models.Roles).
+// Must run after ResolveUser, which sets the caller's role.
+func RequireRole(min string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !models.RoleAtLeast(c.GetString("role"), min) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+			return
+		}
+		c.Next()
+	}
+}
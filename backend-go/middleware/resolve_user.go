@@ -7,20 +7,28 @@ import (
 
 func ResolveUser(store *database.Store) gin.HandlerFunc {
     return func(c *gin.Context) {
-        auth0ID := c.GetString("auth0_id")
-        if auth0ID == "" {
+        if c.GetString("auth0_id") == "" {
             c.AbortWithStatusJSON(401, gin.H{"error": "missing auth0_id in context"})
             return
         }
 
-        user, err := store.GetUserByAuth0ID(c.Request.Context(), auth0ID)
-        if err != nil {
+        if err := resolveViewer(c, store); err != nil {
             c.AbortWithStatusJSON(401, gin.H{"error": "user not found"})
             return
         }
 
-        c.Set("user_id", user.ID)
-        // c.Set("user", user) // snapshot user at the time of middleware. could be stale. therefore not returning
         c.Next()
     }
-}
\ No newline at end of file
+}
+
+// resolveViewer looks up the authenticated caller's user and sets user_id and role.
+func resolveViewer(c *gin.Context, store *database.Store) error {
+	user, err := store.GetUserByAuth0ID(c.Request.Context(), c.GetString("auth0_id"))
+	if err != nil {
+		return err
+	}
+	c.Set("user_id", user.ID)
+	c.Set("role", effectiveRole(c, user.Role))
+	// c.Set("user", user) // snapshot user at the time of middleware. could be stale. therefore not returning
+	return nil
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_concerts_venue_id_date;
+DROP INDEX IF EXISTS idx_venues_city_trgm;
+DROP INDEX IF EXISTS idx_venues_name_trgm;
+DROP INDEX IF EXISTS idx_venues_earth_point;
+
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Venue search + radius queries
+-- ============================================================================
+
+-- earthdistance (on top of cube) gives us ll_to_earth()/earth_box() so radius
+-- queries can use a GiST index instead of scanning every venue. Used by
+-- ListVenuesNearby (GET /venues/nearby).
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- Expression must match venueEarthPoint in database/store_venues.go exactly,
+-- otherwise the planner won't pick this index up.
+CREATE INDEX idx_venues_earth_point
+    ON venues USING GIST (ll_to_earth(latitude, longitude))
+    WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX idx_venues_name_trgm ON venues USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX idx_venues_city_trgm ON venues USING GIN (city gin_trgm_ops) WHERE deleted_at IS NULL;
+
+-- venue history: concerts at a venue ordered by date
+CREATE INDEX idx_concerts_venue_id_date ON concerts (venue_id, date DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_song_performances_song_id_active;
+DROP MATERIALIZED VIEW IF EXISTS song_performance_stats;
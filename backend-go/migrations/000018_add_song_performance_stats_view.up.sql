@@ -0,0 +1,46 @@
+-- ============================================================================
+-- Per-song live statistics, refreshed in the background by SongStatsService
+-- ============================================================================
+
+CREATE MATERIALIZED VIEW song_performance_stats AS
+WITH setlists AS (
+    SELECT
+        sp.id,
+        sp.song_id,
+        sp.position,
+        c.date,
+        MIN(sp.position) OVER (PARTITION BY sp.act_id) AS first_position,
+        MAX(sp.position) OVER (PARTITION BY sp.act_id) AS last_position
+    FROM song_performances sp
+    INNER JOIN acts a ON a.id = sp.act_id AND a.deleted_at IS NULL
+    INNER JOIN concerts c ON c.id = a.concert_id AND c.deleted_at IS NULL
+    WHERE sp.deleted_at IS NULL
+),
+fan_videos AS (
+    SELECT sp.song_id, COUNT(*) AS video_count
+    FROM videos v
+    INNER JOIN song_performances sp ON sp.id = v.song_performance_id AND sp.deleted_at IS NULL
+    WHERE v.deleted_at IS NULL
+      AND v.status = 'completed'
+      AND v.visibility = 'public'
+    GROUP BY sp.song_id
+)
+SELECT
+    s.song_id,
+    COUNT(*)::int                                               AS total_plays,
+    MIN(s.date)                                                 AS first_played_at,
+    MAX(s.date)                                                 AS last_played_at,
+    AVG(s.position)::double precision                           AS avg_position,
+    (COUNT(*) FILTER (WHERE s.position = s.first_position))::int AS opener_count,
+    (COUNT(*) FILTER (WHERE s.position = s.last_position))::int  AS closer_count,
+    COALESCE(MAX(fv.video_count), 0)::int                       AS video_count,
+    NOW()                                                       AS computed_at
+FROM setlists s
+LEFT JOIN fan_videos fv ON fv.song_id = s.song_id
+GROUP BY s.song_id;
+
+-- required for REFRESH MATERIALIZED VIEW CONCURRENTLY
+CREATE UNIQUE INDEX idx_song_performance_stats_song_id ON song_performance_stats (song_id);
+
+-- performances of a song, used by GET /songs/:id/performances
+CREATE INDEX idx_song_performances_song_id_active ON song_performances (song_id, act_id) WHERE deleted_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_venues_lower_city_country;
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Concert search filters
+-- ============================================================================
+
+-- city + country browsing ("all shows in Toronto"). Expression must match the
+-- lower(v.city) comparison in SearchConcerts. The artist filter is already
+-- covered by UNIQUE (concert_id, artist_id) on acts, and date by idx_concerts_date.
+CREATE INDEX idx_venues_lower_city_country
+    ON venues (lower(city), country_code)
+    WHERE deleted_at IS NULL;
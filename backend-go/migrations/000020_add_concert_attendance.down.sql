@@ -0,0 +1 @@
+DROP TABLE IF EXISTS concert_attendance;
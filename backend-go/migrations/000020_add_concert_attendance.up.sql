@@ -0,0 +1,33 @@
+-- ============================================================================
+-- Concert attendance ("I was there")
+-- ============================================================================
+
+-- One row per user per concert. source records how it was created:
+--   manual    — the user marked it
+--   video     — the user uploaded a video linked to the concert
+--   detection — the user confirmed a detected concert for one of their videos
+-- visibility controls whether other users see it in attendee lists and on profiles.
+CREATE TABLE concert_attendance (
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    concert_id INTEGER NOT NULL REFERENCES concerts(id) ON DELETE CASCADE,
+    source     VARCHAR(20) NOT NULL,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, concert_id)
+);
+
+-- attendee lists: newest first per concert
+CREATE INDEX idx_concert_attendance_concert_id_created
+    ON concert_attendance (concert_id, created_at DESC, user_id DESC);
+
+-- backfill from existing uploads, which were the only user -> concert link so far
+INSERT INTO concert_attendance (user_id, concert_id, source, created_at)
+SELECT v.user_id, v.event_id, 'video', MIN(v.created_at)
+FROM videos v
+JOIN concerts c ON c.id = v.event_id
+WHERE v.event_type = 'concert'
+  AND v.status = 'completed'
+  AND v.deleted_at IS NULL
+GROUP BY v.user_id, v.event_id
+ON CONFLICT DO NOTHING;
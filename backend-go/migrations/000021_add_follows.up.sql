@@ -0,0 +1,30 @@
+-- ============================================================================
+-- Follows: users follow other users and artists
+-- ============================================================================
+
+CREATE TABLE user_follows (
+    follower_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+-- follower lists: newest first per followee
+CREATE INDEX idx_user_follows_followee_created ON user_follows (followee_id, created_at DESC, follower_id DESC);
+-- following lists: newest first per follower
+CREATE INDEX idx_user_follows_follower_created ON user_follows (follower_id, created_at DESC, followee_id DESC);
+
+-- Who follows which artist. Also drives the "followed artists" calendar filter.
+CREATE TABLE artist_follows (
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    artist_id  INTEGER NOT NULL REFERENCES artists(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, artist_id)
+);
+
+-- artist follower lists and following-artist lists, newest first
+CREATE INDEX idx_artist_follows_artist_created ON artist_follows (artist_id, created_at DESC, user_id DESC);
+CREATE INDEX idx_artist_follows_user_created ON artist_follows (user_id, created_at DESC, artist_id DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_concerts_created_at;
+DROP INDEX IF EXISTS idx_videos_feed_concert_created;
+DROP INDEX IF EXISTS idx_videos_feed_user_created;
@@ -0,0 +1,15 @@
+-- ============================================================================
+-- Home feed: recent public videos per uploader and per concert.
+-- Partial on the feed's visibility filter so the indexes stay small.
+-- ============================================================================
+
+CREATE INDEX idx_videos_feed_user_created
+    ON videos (user_id, created_at DESC)
+    WHERE deleted_at IS NULL AND status = 'completed' AND visibility = 'public';
+
+CREATE INDEX idx_videos_feed_concert_created
+    ON videos (event_id, created_at DESC)
+    WHERE event_type = 'concert' AND deleted_at IS NULL AND status = 'completed' AND visibility = 'public';
+
+-- new concerts by followed artists
+CREATE INDEX idx_concerts_created_at ON concerts (created_at DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE videos DROP COLUMN IF EXISTS view_count;
+DROP TABLE IF EXISTS video_reactions;
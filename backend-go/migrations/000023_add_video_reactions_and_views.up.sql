@@ -0,0 +1,19 @@
+-- ============================================================================
+-- Video engagement: one reaction per user per video, and a view counter.
+-- view_count is bumped in batches by the API's view buffer, not per request.
+-- ============================================================================
+
+CREATE TABLE video_reactions (
+    video_id   INTEGER NOT NULL REFERENCES videos(id) ON DELETE CASCADE,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reaction   VARCHAR(20) NOT NULL CHECK (reaction IN ('like', 'fire', 'heart', 'laugh', 'wow', 'cry')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (video_id, user_id)
+);
+
+-- a user's own reactions across a page of videos
+CREATE INDEX idx_video_reactions_user_id ON video_reactions (user_id, video_id);
+
+ALTER TABLE videos ADD COLUMN view_count BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS comment_reports;
+DROP TABLE IF EXISTS comment_mentions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS user_blocks;
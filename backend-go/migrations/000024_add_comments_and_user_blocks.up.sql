@@ -0,0 +1,75 @@
+-- ============================================================================
+-- User blocks: a blocked user can't see or reply to the blocker's comments,
+-- comment on their videos, or mention them (and vice versa).
+-- ============================================================================
+
+CREATE TABLE user_blocks (
+    blocker_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- "who blocked me" lookups; (blocker_id, ...) is served by the primary key
+CREATE INDEX idx_user_blocks_blocked_id ON user_blocks (blocked_id, blocker_id);
+
+-- ============================================================================
+-- Comments on videos and concerts. Polymorphic like videos.event_type/event_id.
+-- One level of threading: parent_id always points at a top-level comment.
+-- ============================================================================
+
+CREATE TABLE comments (
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('video', 'concert')),
+    target_id   INTEGER NOT NULL,
+    parent_id   INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+    body        TEXT NOT NULL CHECK (char_length(body) BETWEEN 1 AND 2000),
+    reply_count INTEGER NOT NULL DEFAULT 0,
+    edited_at   TIMESTAMP,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at  TIMESTAMP
+);
+
+-- top-level thread pages, newest first
+CREATE INDEX idx_comments_target_created
+    ON comments (target_type, target_id, created_at DESC, id DESC)
+    WHERE parent_id IS NULL;
+
+-- reply pages, oldest first
+CREATE INDEX idx_comments_parent_created
+    ON comments (parent_id, created_at, id)
+    WHERE parent_id IS NOT NULL;
+
+CREATE TABLE comment_mentions (
+    comment_id INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    PRIMARY KEY (comment_id, user_id)
+);
+
+CREATE INDEX idx_comment_mentions_user_id ON comment_mentions (user_id);
+
+-- ============================================================================
+-- Comment reports: the moderation queue. One report per user per comment.
+-- ============================================================================
+
+CREATE TABLE comment_reports (
+    id          SERIAL PRIMARY KEY,
+    comment_id  INTEGER NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    reporter_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason      VARCHAR(20) NOT NULL CHECK (reason IN ('spam', 'harassment', 'hate', 'sexual', 'other')),
+    details     TEXT,
+    status      VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'actioned')),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+
+    UNIQUE (comment_id, reporter_id)
+);
+
+-- open reports, oldest first
+CREATE INDEX idx_comment_reports_open ON comment_reports (created_at, id) WHERE status = 'open';
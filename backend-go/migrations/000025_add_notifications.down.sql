@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,25 @@
+-- ============================================================================
+-- In-app notifications. Each row is one inbox entry for user_id; the optional
+-- references say what it is about and cascade away with their subject.
+-- ============================================================================
+
+CREATE TABLE notifications (
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(40) NOT NULL,
+    actor_id   INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    video_id   INTEGER REFERENCES videos(id) ON DELETE CASCADE,
+    concert_id INTEGER REFERENCES concerts(id) ON DELETE CASCADE,
+    comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+    data       JSONB NOT NULL DEFAULT '{}',
+    read_at    TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- inbox pages, newest first
+CREATE INDEX idx_notifications_user_created ON notifications (user_id, created_at DESC, id DESC);
+-- unread badge counts
+CREATE INDEX idx_notifications_user_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+-- per-type opt-outs: {"<type>": false}. Types missing from the map are enabled.
+ALTER TABLE users ADD COLUMN notification_preferences JSONB NOT NULL DEFAULT '{}';
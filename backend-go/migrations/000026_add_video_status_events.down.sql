@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trg_videos_status_notify ON videos;
+DROP FUNCTION IF EXISTS notify_video_status_change();
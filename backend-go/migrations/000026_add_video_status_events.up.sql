@@ -0,0 +1,32 @@
+-- ============================================================================
+-- Video status events. Every change to a video's upload, thumbnail or
+-- detection status is published on the video_events channel so each API
+-- replica can push it to the owner's open GET /videos/events streams.
+--
+-- Payload: {"video_id": 1, "user_id": 2, "kind": "thumbnail", "status": "completed"}
+-- ============================================================================
+
+CREATE OR REPLACE FUNCTION notify_video_status_change() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status IS DISTINCT FROM OLD.status THEN
+        PERFORM pg_notify('video_events', json_build_object(
+            'video_id', NEW.id, 'user_id', NEW.user_id, 'kind', 'upload', 'status', NEW.status
+        )::text);
+    END IF;
+    IF NEW.thumbnail_status IS DISTINCT FROM OLD.thumbnail_status THEN
+        PERFORM pg_notify('video_events', json_build_object(
+            'video_id', NEW.id, 'user_id', NEW.user_id, 'kind', 'thumbnail', 'status', NEW.thumbnail_status
+        )::text);
+    END IF;
+    IF NEW.detection_status IS DISTINCT FROM OLD.detection_status THEN
+        PERFORM pg_notify('video_events', json_build_object(
+            'video_id', NEW.id, 'user_id', NEW.user_id, 'kind', 'detection', 'status', NEW.detection_status
+        )::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_videos_status_notify
+    AFTER UPDATE OF status, thumbnail_status, detection_status ON videos
+    FOR EACH ROW EXECUTE FUNCTION notify_video_status_change();
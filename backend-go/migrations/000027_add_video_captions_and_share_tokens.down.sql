@@ -0,0 +1,5 @@
+UPDATE videos SET visibility = 'private' WHERE visibility = 'unlisted';
+ALTER TABLE videos DROP CONSTRAINT IF EXISTS chk_videos_visibility;
+DROP INDEX IF EXISTS idx_videos_share_token;
+ALTER TABLE videos DROP COLUMN IF EXISTS share_token;
+ALTER TABLE videos DROP COLUMN IF EXISTS caption;
@@ -0,0 +1,13 @@
+-- ============================================================================
+-- Video captions and unlisted sharing. An unlisted video never appears in
+-- listings, search or feeds; anyone holding its current share_token can open
+-- it. Rotating or clearing the token revokes every link made from the old one.
+-- ============================================================================
+
+ALTER TABLE videos ADD COLUMN caption TEXT;
+ALTER TABLE videos ADD COLUMN share_token VARCHAR(64);
+
+CREATE UNIQUE INDEX idx_videos_share_token ON videos (share_token) WHERE share_token IS NOT NULL;
+
+ALTER TABLE videos ADD CONSTRAINT chk_videos_visibility
+    CHECK (visibility IN ('public', 'unlisted', 'private'));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_videos_location_strip_queued;
+ALTER TABLE videos DROP COLUMN IF EXISTS location_strip_started_at;
+ALTER TABLE videos DROP COLUMN IF EXISTS location_strip_status;
+ALTER TABLE videos DROP COLUMN IF EXISTS playback_s3_key;
+ALTER TABLE users DROP COLUMN IF EXISTS location_privacy;
@@ -0,0 +1,20 @@
+-- ============================================================================
+-- Location privacy. location_privacy controls how a user's video coordinates
+-- are shown to other viewers: 'precise', 'approximate' (rounded) or 'hidden'.
+-- The stored latitude/longitude stay precise for detection.
+--
+-- When location stripping is enabled, each processed video gets a remuxed copy
+-- without container metadata (ISO 6709 location atoms included) at
+-- playback_s3_key, and that copy is what video_url serves.
+-- ============================================================================
+
+ALTER TABLE users ADD COLUMN location_privacy VARCHAR(20) NOT NULL DEFAULT 'approximate'
+    CHECK (location_privacy IN ('precise', 'approximate', 'hidden'));
+
+ALTER TABLE videos ADD COLUMN playback_s3_key TEXT;
+ALTER TABLE videos ADD COLUMN location_strip_status VARCHAR(20);
+ALTER TABLE videos ADD COLUMN location_strip_started_at TIMESTAMP;
+
+-- strip queue claims
+CREATE INDEX idx_videos_location_strip_queued ON videos (created_at, id)
+    WHERE location_strip_status = 'queued' AND deleted_at IS NULL;
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- User roles. role grants access to moderation ('moderator') and admin
+-- ('admin') endpoints; an Auth0 permissions claim can also grant them per token.
+-- Every change to users.role is recorded in role_changes.
+-- ============================================================================
+
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (role IN ('user', 'moderator', 'admin'));
+
+CREATE TABLE role_changes (
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_role   VARCHAR(20) NOT NULL,
+    new_role   VARCHAR(20) NOT NULL,
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL, -- NULL for the bootstrap CLI
+    source     VARCHAR(20) NOT NULL CHECK (source IN ('api', 'cli')),
+    reason     TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_role_changes_user_created ON role_changes (user_id, created_at DESC, id DESC);
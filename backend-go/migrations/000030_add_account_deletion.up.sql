@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Account deletion. DELETE /users/me sets users.deleted_at (000006) and hides the
+-- account's videos right away; once the grace period is over the purge job erases
+-- the account's content and scrubs the row down to an anonymous tombstone, which
+-- keeps other users' replies under the account's comments intact.
+-- ============================================================================
+
+ALTER TABLE users
+    ADD COLUMN purge_started_at TIMESTAMP, -- lease of the purge run working on the account
+    ADD COLUMN purged_at        TIMESTAMP;
+
+-- accounts waiting to be purged, oldest deletion first
+CREATE INDEX idx_users_pending_purge ON users (deleted_at, id)
+    WHERE deleted_at IS NOT NULL AND purged_at IS NULL;
@@ -0,0 +1,26 @@
+-- ============================================================================
+-- Personal data exports: a ZIP of everything we hold about a user, built by a
+-- background job and stored privately until expires_at.
+-- ============================================================================
+
+CREATE TABLE data_exports (
+    id           SERIAL PRIMARY KEY,
+    user_id      INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status       VARCHAR(20) NOT NULL DEFAULT 'queued'
+                 CHECK (status IN ('queued', 'processing', 'completed', 'failed', 'expired')),
+    s3_key       TEXT,
+    size_bytes   BIGINT,
+    started_at   TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at   TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- a user's exports, newest first
+CREATE INDEX idx_data_exports_user_created ON data_exports (user_id, created_at DESC, id DESC);
+-- job queue
+CREATE INDEX idx_data_exports_queued ON data_exports (created_at, id) WHERE status = 'queued';
+-- archives waiting to expire
+CREATE INDEX idx_data_exports_completed_expires ON data_exports (expires_at) WHERE status = 'completed';
+-- at most one export in flight per user
+CREATE UNIQUE INDEX idx_data_exports_user_active ON data_exports (user_id) WHERE status IN ('queued', 'processing');
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_username_lower;
+ALTER TABLE users DROP COLUMN IF EXISTS merged_into_user_id;
+ALTER TABLE users DROP COLUMN IF EXISTS username_set_at;
+DROP TABLE IF EXISTS account_link_codes;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,56 @@
+-- ============================================================================
+-- Linked identities: every Auth0 subject (google-oauth2|…, apple|…, auth0|…) a
+-- user signs in with maps to one users row. users.auth0_id stays as the subject
+-- the account was created with (until 000035 clears it).
+-- ============================================================================
+
+CREATE TABLE user_identities (
+    subject        VARCHAR(255) PRIMARY KEY,
+    user_id        INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email          VARCHAR(255),                    -- as reported by Auth0 /userinfo
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities (user_id);
+CREATE INDEX idx_user_identities_verified_email ON user_identities (LOWER(email)) WHERE email_verified;
+
+-- every existing account's first identity. The old sync never asked Auth0 whether
+-- an email was verified, so these start unverified: a new identity is only merged
+-- into one of these accounts by verified email after the account's original
+-- identity has signed in again and /userinfo has confirmed its email.
+INSERT INTO user_identities (subject, user_id, email, created_at, last_seen_at)
+SELECT auth0_id, id, email, created_at, updated_at
+FROM users
+WHERE purged_at IS NULL;
+
+-- One-time codes that let a user signed in with one identity attach another
+-- identity (or merge another account) into the account that issued the code.
+CREATE TABLE account_link_codes (
+    code_hash  VARCHAR(64) PRIMARY KEY, -- hex SHA-256 of the code
+    user_id    INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_link_codes_user_id ON account_link_codes (user_id);
+
+-- username_set_at: when the user picked their username in the app. Until then
+-- /users/sync keeps the username in step with the identity provider.
+-- merged_into_user_id: set on accounts folded into another one.
+ALTER TABLE users
+    ADD COLUMN username_set_at     TIMESTAMP,
+    ADD COLUMN merged_into_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- The old sync stored whatever username the client sent, so some may differ only
+-- by case. The oldest account keeps its username; the others get an _<id> suffix.
+UPDATE users u
+SET username = LEFT(u.username, 255 - LENGTH('_' || u.id)) || '_' || u.id, updated_at = NOW()
+WHERE EXISTS (
+    SELECT 1 FROM users o
+    WHERE LOWER(o.username) = LOWER(u.username) AND o.id < u.id
+);
+
+-- usernames are unique regardless of case; also serves case-insensitive lookups
+CREATE UNIQUE INDEX idx_users_username_lower ON users (LOWER(username));
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Personal access tokens: long-lived, scoped bearer tokens for scripts (e.g.
+-- bulk uploads) that can't go through the Auth0 browser flow. Only a SHA-256
+-- hash of each token is stored; the token itself is shown once, on creation.
+-- ============================================================================
+
+CREATE TABLE personal_access_tokens (
+    id           SERIAL PRIMARY KEY,
+    user_id      INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    token_hash   VARCHAR(64) NOT NULL UNIQUE,  -- hex SHA-256 of the token
+    token_prefix VARCHAR(16) NOT NULL,         -- first characters, to tell tokens apart in lists
+    scopes       TEXT[] NOT NULL,
+    expires_at   TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- a user's tokens, newest first
+CREATE INDEX idx_personal_access_tokens_user_created ON personal_access_tokens (user_id, created_at DESC, id DESC);
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Token buckets for the Postgres rate limiter (RATE_LIMIT_BACKEND=postgres),
+-- shared by every replica. A bucket only records when it will be full again:
+-- each request spends one emission interval, and a bucket that is full is the
+-- same as no row at all, so full buckets are deleted periodically.
+-- Unlogged: losing buckets in a crash just resets everyone's limits.
+-- ============================================================================
+
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    key     TEXT PRIMARY KEY,   -- "<group>:user:<id>" or "<group>:ip:<addr>"
+    full_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_buckets_full_at ON rate_limit_buckets (full_at);
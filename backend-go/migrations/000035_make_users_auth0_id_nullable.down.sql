@@ -0,0 +1,9 @@
+-- give every account back its oldest linked subject, or a tombstone marker if it has none
+UPDATE users u
+SET auth0_id = COALESCE(
+    (SELECT i.subject FROM user_identities i WHERE i.user_id = u.id ORDER BY i.created_at, i.subject LIMIT 1),
+    'deleted|' || u.id
+)
+WHERE u.auth0_id IS NULL;
+
+ALTER TABLE users ALTER COLUMN auth0_id SET NOT NULL;
@@ -0,0 +1,12 @@
+-- ============================================================================
+-- user_identities (000032) is the source of truth for which Auth0 subjects sign
+-- in to which account. users.auth0_id stops holding a subject: while it stayed
+-- UNIQUE, an identity that was unlinked (or moved away in a merge) from the
+-- account it created could never sign up again, because its subject was still
+-- taken in users.auth0_id. New accounts leave it NULL and existing ones are
+-- cleared; purged tombstones keep their 'deleted|<id>' marker.
+-- ============================================================================
+
+ALTER TABLE users ALTER COLUMN auth0_id DROP NOT NULL;
+
+UPDATE users SET auth0_id = NULL WHERE purged_at IS NULL;
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Attendance records that a user was at a concert ("I was there").
+// Source says how the record was created; Visibility says who else can see it.
+type Attendance struct {
+	UserID     int       `db:"user_id" json:"user_id"`
+	ConcertID  int       `db:"concert_id" json:"concert_id"`
+	Source     string    `db:"source" json:"source"`
+	Visibility string    `db:"visibility" json:"visibility"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// Attendance source constants
+const (
+	AttendanceSourceManual    = "manual"
+	AttendanceSourceVideo     = "video"
+	AttendanceSourceDetection = "detection"
+)
+
+const (
+	AttendanceVisibilityPrivate = "private"
+	AttendanceVisibilityPublic  = "public"
+)
+
+// Attendee is a user paired with when they were recorded at a concert.
+// Not a table — produced by attendee list queries.
+type Attendee struct {
+	User
+	AttendedAt time.Time `json:"attended_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Comment is a comment on a video or concert (TargetType/TargetID, like Video's event).
+// Threads are one level deep: ParentID, when set, is always a top-level comment.
+// Deleted comments with replies stay in the thread as placeholders.
+type Comment struct {
+	ID         int        `db:"id" json:"id"`
+	UserID     int        `db:"user_id" json:"user_id"`
+	TargetType string     `db:"target_type" json:"target_type"`
+	TargetID   int        `db:"target_id" json:"target_id"`
+	ParentID   *int       `db:"parent_id" json:"parent_id,omitempty"`
+	Body       string     `db:"body" json:"body"`
+	ReplyCount int        `db:"reply_count" json:"reply_count"`
+	EditedAt   *time.Time `db:"edited_at" json:"edited_at,omitempty"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at" json:"updated_at"`
+	DeletedAt  *time.Time `db:"deleted_at" json:"-"`
+}
+
+// Comment target type constants
+const (
+	CommentTargetVideo   = "video"
+	CommentTargetConcert = "concert"
+)
+
+// CommentReport is a user's report of a comment, queued for moderation.
+type CommentReport struct {
+	ID         int        `db:"id" json:"id"`
+	CommentID  int        `db:"comment_id" json:"comment_id"`
+	ReporterID int        `db:"reporter_id" json:"reporter_id"`
+	Reason     string     `db:"reason" json:"reason"`
+	Details    *string    `db:"details" json:"details,omitempty"`
+	Status     string     `db:"status" json:"status"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	ResolvedAt *time.Time `db:"resolved_at" json:"resolved_at,omitempty"`
+	ResolvedBy *int       `db:"resolved_by" json:"resolved_by,omitempty"`
+}
+
+// ReportedComment is a comment report together with the comment it is about.
+// Not a table — produced by the moderation queue query.
+type ReportedComment struct {
+	Report  CommentReport
+	Comment Comment
+}
+
+// Comment report reason constants
+const (
+	CommentReportReasonSpam       = "spam"
+	CommentReportReasonHarassment = "harassment"
+	CommentReportReasonHate       = "hate"
+	CommentReportReasonSexual     = "sexual"
+	CommentReportReasonOther      = "other"
+)
+
+// Comment report status constants
+const (
+	CommentReportStatusOpen      = "open"
+	CommentReportStatusDismissed = "dismissed"
+	CommentReportStatusActioned  = "actioned"
+)
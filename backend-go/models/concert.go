@@ -26,3 +26,10 @@ type Concert struct {
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
 	DeletedAt *time.Time `db:"deleted_at" json:"-"`
 }
+
+// CalendarConcert is a concert paired with the number of concerts on the same day.
+// Not a table — produced by calendar queries, which cap how many concerts each day lists.
+type CalendarConcert struct {
+	Concert
+	DayConcertCount int `json:"day_concert_count"`
+}
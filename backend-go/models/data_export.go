@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// DataExport is a user's request for an archive of their personal data.
+// The archive lives in private storage until ExpiresAt.
+type DataExport struct {
+	ID          int        `db:"id" json:"id"`
+	UserID      int        `db:"user_id" json:"user_id"`
+	Status      string     `db:"status" json:"status"`
+	S3Key       *string    `db:"s3_key" json:"-"`
+	SizeBytes   *int64     `db:"size_bytes" json:"size_bytes,omitempty"`
+	StartedAt   *time.Time `db:"started_at" json:"-"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `db:"expires_at" json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+
+	// presigned link to the archive; not a column, set for completed exports
+	DownloadURL *string `db:"-" json:"download_url,omitempty"`
+}
+
+// Data export status constants
+const (
+	DataExportStatusQueued     = "queued"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusCompleted  = "completed"
+	DataExportStatusFailed     = "failed"
+	DataExportStatusExpired    = "expired" // archive deleted from storage
+)
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Feed reason constants — why an entry is in a user's home feed.
+const (
+	FeedReasonFollowedUser   = "followed_user"
+	FeedReasonAttended       = "attended"
+	FeedReasonFollowedArtist = "followed_artist"
+)
+
+// FeedEntry is one card of a user's home feed: all new activity at one concert,
+// or a single video that isn't linked to a concert.
+// Not a table — produced by the home feed query, which collapses every candidate
+// at the same concert into one entry.
+type FeedEntry struct {
+	ConcertID  *int      // nil for a video with no concert
+	VideoID    *int      // set only when ConcertID is nil
+	ActivityAt time.Time // newest activity in the entry
+	Reasons    []string
+	VideoCount int
+	VideoIDs   []int // newest first, capped at the preview size
+}
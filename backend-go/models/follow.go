@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// FollowedUser is a user in a follower/following list, paired with when the follow happened.
+// Not a table — produced by follow list queries over user_follows and artist_follows.
+type FollowedUser struct {
+	User
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+// FollowedArtist is an artist in a user's following list, paired with when the follow happened.
+type FollowedArtist struct {
+	Artist
+	FollowedAt time.Time `json:"followed_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// UserIdentity is one Auth0 subject a user signs in with. A user has at least one.
+type UserIdentity struct {
+	Subject       string    `db:"subject" json:"subject"`
+	UserID        int       `db:"user_id" json:"user_id"`
+	Email         *string   `db:"email" json:"email,omitempty"`
+	EmailVerified bool      `db:"email_verified" json:"email_verified"`
+	CreatedAt     time.Time `db:"created_at" json:"created_at"`
+	LastSeenAt    time.Time `db:"last_seen_at" json:"last_seen_at"`
+}
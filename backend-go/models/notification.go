@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Notification is one entry in a user's in-app inbox.
+// ActorID is the user who caused it (nil for system events like processing);
+// VideoID/ConcertID/CommentID point at what it is about.
+type Notification struct {
+	ID        int            `db:"id" json:"id"`
+	UserID    int            `db:"user_id" json:"user_id"`
+	Type      string         `db:"type" json:"type"`
+	ActorID   *int           `db:"actor_id" json:"actor_id,omitempty"`
+	VideoID   *int           `db:"video_id" json:"video_id,omitempty"`
+	ConcertID *int           `db:"concert_id" json:"concert_id,omitempty"`
+	CommentID *int           `db:"comment_id" json:"comment_id,omitempty"`
+	Data      map[string]any `db:"data" json:"data,omitempty"`
+	ReadAt    *time.Time     `db:"read_at" json:"read_at,omitempty"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+}
+
+// Notification type constants — also the keys of a user's notification preferences.
+const (
+	NotificationVideoProcessed        = "video_processed" // thumbnail pipeline finished
+	NotificationVideoProcessingFailed = "video_processing_failed"
+	NotificationDetectionCandidates   = "detection_candidates" // concert candidates await confirmation
+	NotificationComment               = "comment"              // someone commented on your video
+	NotificationCommentReply          = "comment_reply"        // someone replied to your comment
+	NotificationMention               = "mention"              // someone @mentioned you
+	NotificationFollow                = "follow"               // someone followed you
+	NotificationDataExportReady       = "data_export_ready"    // your data export can be downloaded
+	NotificationDataExportFailed      = "data_export_failed"
+)
+
+// NotificationTypes lists every notification type, in the order preferences are shown.
+var NotificationTypes = []string{
+	NotificationVideoProcessed,
+	NotificationVideoProcessingFailed,
+	NotificationDetectionCandidates,
+	NotificationComment,
+	NotificationCommentReply,
+	NotificationMention,
+	NotificationFollow,
+	NotificationDataExportReady,
+	NotificationDataExportFailed,
+}
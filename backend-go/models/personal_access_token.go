@@ -0,0 +1,41 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// PersonalAccessToken is a scoped bearer token a user created for scripted access.
+// Only its hash is stored; the token itself is returned once, when it is created.
+type PersonalAccessToken struct {
+	ID          int        `db:"id" json:"id"`
+	UserID      int        `db:"user_id" json:"-"`
+	Name        string     `db:"name" json:"name"`
+	TokenHash   string     `db:"token_hash" json:"-"`
+	TokenPrefix string     `db:"token_prefix" json:"token_prefix"`
+	Scopes      []string   `db:"scopes" json:"scopes"`
+	ExpiresAt   time.Time  `db:"expires_at" json:"expires_at"`
+	LastUsedAt  *time.Time `db:"last_used_at" json:"last_used_at"`
+	RevokedAt   *time.Time `db:"revoked_at" json:"-"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+}
+
+// PersonalAccessTokenPrefix starts every personal access token, so the auth
+// middleware can tell them apart from Auth0 JWTs without a lookup.
+const PersonalAccessTokenPrefix = "rlv_pat_"
+
+// Token scope constants. A token can only reach routes that require one of its scopes.
+const (
+	TokenScopeRead        = "read"         // read the owner's account and videos
+	TokenScopeVideosWrite = "videos:write" // upload videos and edit their settings
+)
+
+// TokenScopes lists the valid token scopes.
+var TokenScopes = []string{TokenScopeRead, TokenScopeVideosWrite}
+
+// HashPersonalAccessToken is the stored form of a token: hex SHA-256.
+func HashPersonalAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
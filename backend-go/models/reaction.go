@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Reaction is a user's single reaction to a video. Reacting again replaces it.
+type Reaction struct {
+	VideoID   int       `db:"video_id" json:"video_id"`
+	UserID    int       `db:"user_id" json:"user_id"`
+	Reaction  string    `db:"reaction" json:"reaction"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// Reaction constants — the fixed set video_reactions accepts.
+const (
+	ReactionLike  = "like"
+	ReactionFire  = "fire"
+	ReactionHeart = "heart"
+	ReactionLaugh = "laugh"
+	ReactionWow   = "wow"
+	ReactionCry   = "cry"
+)
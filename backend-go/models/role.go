@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Role constants, lowest to highest. Each role can do everything the ones below it can.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Roles lists the valid roles, lowest to highest.
+var Roles = []string{RoleUser, RoleModerator, RoleAdmin}
+
+// RoleAtLeast reports whether role grants at least min. Unknown roles grant nothing.
+func RoleAtLeast(role string, min string) bool {
+	return roleRank(role) >= roleRank(min) && roleRank(role) > 0
+}
+
+// HigherRole returns the higher of two roles.
+func HigherRole(a string, b string) string {
+	if roleRank(b) > roleRank(a) {
+		return b
+	}
+	return a
+}
+
+func roleRank(role string) int {
+	for i, r := range Roles {
+		if r == role {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Role change source constants — where a role change was made.
+const (
+	RoleChangeSourceAPI = "api"
+	RoleChangeSourceCLI = "cli"
+)
+
+// RoleChange is one entry of the role audit trail.
+type RoleChange struct {
+	ID        int       `db:"id" json:"id"`
+	UserID    int       `db:"user_id" json:"user_id"`
+	OldRole   string    `db:"old_role" json:"old_role"`
+	NewRole   string    `db:"new_role" json:"new_role"`
+	ChangedBy *int      `db:"changed_by" json:"changed_by,omitempty"` // nil for the bootstrap CLI
+	Source    string    `db:"source" json:"source"`
+	Reason    *string   `db:"reason" json:"reason,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
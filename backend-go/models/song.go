@@ -28,3 +28,10 @@ type Song struct {
 	CreatedAt              time.Time  `db:"created_at" json:"created_at"`
 	DeletedAt              *time.Time `db:"deleted_at" json:"-"`
 }
+
+// PerformedSong is a song paired with how many times a given artist has played it live.
+// Not a table — produced by per-artist setlist aggregation.
+type PerformedSong struct {
+	Song
+	TimesPerformed int `json:"times_performed"`
+}
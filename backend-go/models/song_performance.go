@@ -22,3 +22,24 @@ type SongPerformance struct {
 	DeletedAt *time.Time `db:"deleted_at" json:"-"`
 }
 
+// SongPlay is a song performance joined with the concert it happened at and the performing artist.
+// Not a table — produced when listing a song's performances across concerts.
+type SongPlay struct {
+	SongPerformance
+	PerformerArtistID int
+	Concert           Concert
+}
+
+// SongPerformanceStats is a row of the song_performance_stats materialized view.
+// Values are as of ComputedAt — the view is refreshed in the background, not on write.
+type SongPerformanceStats struct {
+	SongID        int        `db:"song_id" json:"song_id"`
+	TotalPlays    int        `db:"total_plays" json:"total_plays"`
+	FirstPlayedAt *time.Time `db:"first_played_at" json:"first_played_at,omitempty"`
+	LastPlayedAt  *time.Time `db:"last_played_at" json:"last_played_at,omitempty"`
+	AvgPosition   *float64   `db:"avg_position" json:"avg_position,omitempty"`
+	OpenerCount   int        `db:"opener_count" json:"opener_count"`
+	CloserCount   int        `db:"closer_count" json:"closer_count"`
+	VideoCount    int        `db:"video_count" json:"video_count"`
+	ComputedAt    *time.Time `db:"computed_at" json:"computed_at,omitempty"`
+}
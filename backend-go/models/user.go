@@ -5,13 +5,34 @@ import "time"
 // User represents a registered user (with Auth0)
 type User struct {
 	ID                int       `db:"id" json:"id"`
-	Auth0ID           string    `db:"auth0_id" json:"auth0_id"`
+	Auth0ID           *string   `db:"auth0_id" json:"auth0_id,omitempty"` // legacy; NULL for new accounts, user_identities holds every subject
 	Email             string    `db:"email" json:"email"`
 	Username          string    `db:"username" json:"username"`
 	DisplayName       string    `db:"display_name" json:"display_name"`
 	ProfilePictureURL *string   `db:"profile_picture" json:"profile_picture"` // Nullable
 	Bio               *string   `db:"bio" json:"bio"`                         // Nullable
+	LocationPrivacy   string    `db:"location_privacy" json:"location_privacy"`
+	Role              string    `db:"role" json:"role"`
+	UsernameSetAt     *time.Time `db:"username_set_at" json:"-"` // set once the user picks a username in the app
 	CreatedAt         time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
 	DeletedAt         *time.Time `db:"deleted_at" json:"-"` // Nullable
 }
+
+// Location privacy constants — how a user's video coordinates are shown to other viewers.
+const (
+	LocationPrivacyPrecise     = "precise"
+	LocationPrivacyApproximate = "approximate"
+	LocationPrivacyHidden      = "hidden"
+)
+
+// UserProfileCounts are the activity totals shown on a profile.
+// Not a table — computed per request, honoring what the viewer is allowed to see.
+type UserProfileCounts struct {
+	Videos           int `json:"videos"`
+	ConcertsAttended int `json:"concerts_attended"`
+	ArtistsSeen      int `json:"artists_seen"`
+	Followers        int `json:"followers"`
+	Following        int `json:"following"`         // users
+	FollowingArtists int `json:"following_artists"` // artists
+}
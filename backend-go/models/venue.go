@@ -19,3 +19,10 @@ type Venue struct {
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
 	DeletedAt *time.Time `db:"deleted_at" json:"-"`
 }
+
+// NearbyVenue is a venue paired with its great-circle distance from a query point.
+// Not a table — produced by radius queries (venues near me, concert detection).
+type NearbyVenue struct {
+	Venue
+	DistanceMeters float64 `json:"distance_meters"`
+}
@@ -11,9 +11,12 @@ type Video struct {
 	S3Key        string     `db:"s3_key" json:"-"`
 	VideoURL     string     `db:"video_url" json:"video_url"`
 	ThumbnailURL *string    `db:"thumbnail_url" json:"thumbnail_url"` // Nullable
+	PlaybackS3Key *string   `db:"playback_s3_key" json:"-"` // location-stripped copy video_url serves; nil until stripped
 
 	Status       string     `db:"status" json:"status"`
 	Visibility   string     `db:"visibility" json:"visibility"`
+	Caption      *string    `db:"caption" json:"caption"`
+	ShareToken   *string    `db:"share_token" json:"share_token,omitempty"` // unlisted videos only; shown to the owner
 
 	EventType           *string `db:"event_type" json:"event_type"`
 	EventID             *int    `db:"event_id" json:"event_id"`
@@ -31,6 +34,17 @@ type Video struct {
 	ThumbnailStatus             *string    `db:"thumbnail_status" json:"thumbnail_status,omitempty"`
 	ThumbnailProcessingStartedAt *time.Time `db:"thumbnail_processing_started_at" json:"-"`
 	DetectionStatus             *string    `db:"detection_status" json:"detection_status,omitempty"`
+	LocationStripStatus          *string    `db:"location_strip_status" json:"-"`
+	LocationStripStartedAt       *time.Time `db:"location_strip_started_at" json:"-"`
+
+	ViewCount int64 `db:"view_count" json:"view_count"`
+
+	// engagement — not columns; filled in per response by ReactionService.Attach
+	ReactionCounts map[string]int `db:"-" json:"reaction_counts"`
+	MyReaction     *string        `db:"-" json:"my_reaction,omitempty"` // only set for authenticated viewers
+
+	// set when video_url/thumbnail_url are presigned (non-public videos); refetch the video after it
+	URLsExpireAt *time.Time `db:"-" json:"urls_expire_at,omitempty"`
 
 	CreatedAt    time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt    time.Time  `db:"updated_at" json:"updated_at"`
@@ -45,9 +59,12 @@ const (
 	VideoStatusFailed        = "failed"
 )
 
+// Video visibility constants. Unlisted videos are left out of every listing and
+// only open for their owner or with the video's share token.
 const (
-	VideoVisibilityPrivate = "private"
-	VideoVisibilityPublic  = "public"
+	VideoVisibilityPrivate  = "private"
+	VideoVisibilityUnlisted = "unlisted"
+	VideoVisibilityPublic   = "public"
 )
 
 const (
@@ -62,6 +79,14 @@ const (
 	VideoThumbnailStatusFailed     = "failed"
 )
 
+// Video location strip status constants — tracks the optional metadata-stripping remux
+const (
+	VideoLocationStripStatusQueued     = "queued"
+	VideoLocationStripStatusProcessing = "processing"
+	VideoLocationStripStatusCompleted  = "completed"
+	VideoLocationStripStatusFailed     = "failed"
+)
+
 // Video detection status constants — set after concert/detect is called
 const (
 	VideoDetectionStatusDetected    = "detected"
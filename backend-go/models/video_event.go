@@ -0,0 +1,17 @@
+package models
+
+// Video event kinds — which of a video's statuses a VideoEvent reports on.
+const (
+	VideoEventKindUpload    = "upload"
+	VideoEventKindThumbnail = "thumbnail"
+	VideoEventKindDetection = "detection"
+)
+
+// VideoEvent is one status transition of a video.
+// Not a table — published on the video_events channel by a trigger on videos.
+type VideoEvent struct {
+	VideoID int    `json:"video_id"`
+	UserID  int    `json:"user_id"`
+	Kind    string `json:"kind"`
+	Status  string `json:"status"`
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const (
+	accountPurgeBatchSize = 10        // accounts claimed per purge pass
+	accountPurgeLease     = time.Hour // how long a purge run holds an account before another run may take it over
+	purgeVideoBatchSize   = 50        // videos loaded at a time while purging an account
+)
+
+// AccountDeletionService deletes accounts. Deleting soft-deletes the account at once;
+// a background loop purges accounts whose grace period is over. Every purge step can
+// be re-run, so a purge that dies midway is finished by a later pass.
+type AccountDeletionService struct {
+	store         *database.Store
+	upload        *UploadService
+	playback      *PlaybackService
+	gracePeriod   time.Duration
+	purgeInterval time.Duration
+}
+
+func NewAccountDeletionService(store *database.Store, upload *UploadService, playback *PlaybackService, gracePeriod time.Duration, purgeInterval time.Duration) *AccountDeletionService {
+	return &AccountDeletionService{
+		store:         store,
+		upload:        upload,
+		playback:      playback,
+		gracePeriod:   gracePeriod,
+		purgeInterval: purgeInterval,
+	}
+}
+
+// Delete soft-deletes the user's account, hiding their profile and videos right away.
+// Public video objects are made private too, so the CDN stops serving them during the
+// grace period. A failed ACL change is only logged: the account is already deleted and
+// the purge removes the objects at the end of the grace period either way.
+func (s *AccountDeletionService) Delete(ctx context.Context, userID int) error {
+	if err := s.store.SoftDeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	videos, err := s.store.ListVideosHiddenByDeletion(ctx, userID)
+	if err != nil {
+		log.Printf("[account-deletion] failed to list videos of user %d: %v", userID, err)
+		return nil
+	}
+	for _, video := range videos {
+		if video.Visibility != models.VideoVisibilityPublic {
+			continue
+		}
+		hidden := *video
+		hidden.Visibility = models.VideoVisibilityPrivate
+		if err := s.playback.SyncAccess(ctx, &hidden); err != nil {
+			log.Printf("[account-deletion] failed to make video %d private: %v", video.ID, err)
+		}
+	}
+	return nil
+}
+
+// Restore brings back a deleted account whose purge hasn't started, with the videos the
+// deletion hid, and makes its public videos CDN-readable again.
+func (s *AccountDeletionService) Restore(ctx context.Context, username string) (*models.User, error) {
+	userID, err := s.store.RestoreDeletedUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	videos, err := s.store.ListAllUserVideos(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, video := range videos {
+		if video.Status != models.VideoStatusCompleted || video.Visibility != models.VideoVisibilityPublic {
+			continue
+		}
+		if err := s.playback.SyncAccess(ctx, video); err != nil {
+			return nil, fmt.Errorf("failed to make video %d public: %w", video.ID, err)
+		}
+	}
+	return s.store.GetUserByID(ctx, userID)
+}
+
+// Start launches the purge loop in a background goroutine.
+func (s *AccountDeletionService) Start(ctx context.Context) {
+	go s.runPurgeLoop(ctx)
+	log.Println("[account-purge] started")
+}
+
+// runPurgeLoop purges due accounts once on start, then every purgeInterval until ctx is done.
+func (s *AccountDeletionService) runPurgeLoop(ctx context.Context) {
+	s.purgeDue(ctx)
+
+	ticker := time.NewTicker(s.purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.purgeDue(ctx)
+		}
+	}
+}
+
+// purgeDue claims and purges accounts past their grace period until none are left.
+func (s *AccountDeletionService) purgeDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		now := time.Now()
+		userIDs, err := s.store.ClaimAccountPurges(ctx, now.Add(-s.gracePeriod), now.Add(-accountPurgeLease), accountPurgeBatchSize)
+		if err != nil {
+			log.Printf("[account-purge] failed to claim accounts: %v", err)
+			return
+		}
+		if len(userIDs) == 0 {
+			return
+		}
+
+		for _, userID := range userIDs {
+			if err := s.purge(ctx, userID); err != nil {
+				// the lease runs out and a later pass resumes where this one stopped
+				log.Printf("[account-purge] failed to purge user %d: %v", userID, err)
+				continue
+			}
+			log.Printf("[account-purge] purged user %d", userID)
+		}
+	}
+}
+
+// purge erases a deleted user's videos and data export archives from storage, scrubs
+// their comments, and finally anonymizes the users row.
+func (s *AccountDeletionService) purge(ctx context.Context, userID int) error {
+	for {
+		videos, err := s.store.ListVideosForPurge(ctx, userID, purgeVideoBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list videos: %w", err)
+		}
+		if len(videos) == 0 {
+			break
+		}
+		for _, video := range videos {
+			if err := s.purgeVideo(ctx, video); err != nil {
+				return fmt.Errorf("failed to purge video %d: %w", video.ID, err)
+			}
+		}
+	}
+
+	exportKeys, err := s.store.ListDataExportKeys(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list data exports: %w", err)
+	}
+	for _, key := range exportKeys {
+		if err := s.upload.DeleteObject(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete data export: %w", err)
+		}
+	}
+
+	if err := s.store.ScrubUserComments(ctx, userID); err != nil {
+		return fmt.Errorf("failed to scrub comments: %w", err)
+	}
+	if err := s.store.CompleteAccountPurge(ctx, userID); err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	return nil
+}
+
+// purgeVideo deletes a video's storage objects, then its row. Objects go first so a
+// failure never leaves objects behind without a row to find them by.
+func (s *AccountDeletionService) purgeVideo(ctx context.Context, video *models.Video) error {
+	keys := []string{video.S3Key, videoPlaybackKey(video.ID), videoThumbnailKey(video.ID)}
+	if video.PlaybackS3Key != nil && *video.PlaybackS3Key != videoPlaybackKey(video.ID) {
+		keys = append(keys, *video.PlaybackS3Key)
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.upload.DeleteObject(ctx, key); err != nil {
+			return err
+		}
+	}
+	return s.store.PurgeVideo(ctx, video.ID)
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// AdminService backs the admin and moderation endpoints. Callers are expected to
+// have passed middleware.RequireRole already; nothing here re-checks the caller's role.
+type AdminService struct {
+	store         *database.Store
+	searchService *SearchService
+}
+
+func NewAdminService(store *database.Store, searchService *SearchService) *AdminService {
+	return &AdminService{store: store, searchService: searchService}
+}
+
+// roleChangeCursor is the keyset position of the last role change on a page.
+type roleChangeCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+// commentReportCursor is the keyset position of the last report on a moderation queue page.
+type commentReportCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+// SetRole gives the user a new role on behalf of actorID, recording the change in the
+// audit trail. Admins can't change their own role, so the last admin can't lock everyone out.
+func (s *AdminService) SetRole(ctx context.Context, actorID int, username string, req dto.SetRoleRequest) (*models.User, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == actorID {
+		return nil, apperr.ErrSelfRoleChange
+	}
+
+	if _, err := s.store.SetUserRole(ctx, user.ID, req.Role, &actorID, models.RoleChangeSourceAPI, req.Reason); err != nil {
+		return nil, err
+	}
+	user.Role = req.Role
+	return user, nil
+}
+
+// MergeUsers folds username's account into the account named into. Both must have
+// signed in with the same verified email, which stands in for the link-code proof
+// the users themselves would give.
+func (s *AdminService) MergeUsers(ctx context.Context, username string, into string) (*models.User, error) {
+	source, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.store.GetUserByUsername(ctx, into)
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		return nil, apperr.ErrSelfMerge
+	}
+
+	shared, err := s.store.ShareVerifiedEmail(ctx, source.ID, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, apperr.ErrMergeEmailMismatch
+	}
+
+	if err := s.store.MergeUsers(ctx, source.ID, target.ID); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// ListRoleChanges pages through a user's role audit trail, newest first.
+func (s *AdminService) ListRoleChanges(ctx context.Context, username string, req dto.PageRequest) (*dto.RoleChangesResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeRoleChanges, EntityID: user.ID}
+	var afterCreatedAt *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after roleChangeCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
+
+	changes, err := s.store.ListRoleChanges(ctx, user.ID, afterCreatedAt, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	changes, hasMore := trimPage(changes, req.Limit)
+
+	var last *roleChangeCursor
+	if len(changes) > 0 {
+		r := changes[len(changes)-1]
+		last = &roleChangeCursor{CreatedAt: r.CreatedAt, ID: r.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RoleChangesResponse{Results: changes, Meta: meta}, nil
+}
+
+// ListCommentReports pages through the comment moderation queue, oldest report first.
+func (s *AdminService) ListCommentReports(ctx context.Context, req dto.CommentReportsRequest) (*dto.CommentReportsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	status := req.Status
+	if status == "" {
+		status = models.CommentReportStatusOpen
+	}
+
+	scope := PageScope{Name: pageScopeCommentReports, Query: status}
+	var afterCreatedAt *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after commentReportCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
+
+	reports, err := s.store.ListCommentReports(ctx, status, afterCreatedAt, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	reports, hasMore := trimPage(reports, req.Limit)
+
+	results := make([]dto.CommentReportItem, 0, len(reports))
+	for _, rc := range reports {
+		results = append(results, dto.CommentReportItem{
+			Report:         rc.Report,
+			Comment:        rc.Comment,
+			CommentDeleted: rc.Comment.DeletedAt != nil,
+		})
+	}
+
+	var last *commentReportCursor
+	if len(reports) > 0 {
+		r := reports[len(reports)-1].Report
+		last = &commentReportCursor{CreatedAt: r.CreatedAt, ID: r.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CommentReportsResponse{Results: results, Meta: meta}, nil
+}
+
+// ResolveCommentReport acts on a report for moderatorID: removing deletes the comment,
+// dismissing leaves it up. Either way every open report of that comment is closed;
+// the number closed is returned.
+func (s *AdminService) ResolveCommentReport(ctx context.Context, moderatorID int, reportID int, req dto.ResolveCommentReportRequest) (int, error) {
+	report, err := s.store.GetCommentReportByID(ctx, reportID)
+	if err != nil {
+		return 0, err
+	}
+	if report.Status != models.CommentReportStatusOpen {
+		return 0, apperr.ErrReportResolved
+	}
+
+	status := models.CommentReportStatusDismissed
+	if req.Action == dto.ModerationActionRemove {
+		if err := s.store.SoftDeleteComment(ctx, report.CommentID); err != nil {
+			return 0, err
+		}
+		status = models.CommentReportStatusActioned
+	}
+	return s.store.ResolveCommentReports(ctx, report.CommentID, status, moderatorID)
+}
@@ -2,41 +2,98 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 )
 
 type ArtistService struct {
-	store         *database.Store
-	searchService *SearchService
+	store           *database.Store
+	searchService   *SearchService
+	concertService  *ConcertService
+	reactionService *ReactionService
+	locationPrivacy *LocationPrivacyService
 }
 
-func NewArtistService(store *database.Store, searchService *SearchService) *ArtistService {
-	return &ArtistService{store: store, searchService: searchService}
+func NewArtistService(store *database.Store, searchService *SearchService, concertService *ConcertService, reactionService *ReactionService, locationPrivacy *LocationPrivacyService) *ArtistService {
+	return &ArtistService{store: store, searchService: searchService, concertService: concertService, reactionService: reactionService, locationPrivacy: locationPrivacy}
 }
 
-func (s *ArtistService) Get(ctx context.Context, id int) (*models.Artist, error) {
-	return s.store.GetArtistByID(ctx, id)
+// keyset positions encoded into artist page cursors
+type artistConcertCursor struct {
+	When string    `json:"w"`
+	Date time.Time `json:"d"`
+	ID   int       `json:"id"`
 }
 
-func (s *ArtistService) Search(ctx context.Context, req dto.SearchRequest) (*dto.ArtistSearchResponse, error) {
-	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
+type artistSongCursor struct {
+	TimesPerformed int `json:"n"`
+	ID             int `json:"id"`
+}
+
+// Get returns an artist with its follower count, and whether viewerID follows it
+// when the caller is authenticated (viewerID != 0).
+func (s *ArtistService) Get(ctx context.Context, id int, viewerID int) (*dto.ArtistDetail, error) {
+	artist, err := s.store.GetArtistByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	artists, err := s.store.SearchArtists(ctx, req.Q, req.MaxResults)
+	followers, err := s.store.CountArtistFollowers(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	results := s.BuildSearchResults(artists)
-	meta := s.searchService.BuildSearchMeta(req, len(results))
+	detail := &dto.ArtistDetail{Artist: *artist, FollowersCount: followers}
+	if viewerID != 0 {
+		followed, err := s.store.ListFollowedArtistIDs(ctx, viewerID, []int{id})
+		if err != nil {
+			return nil, err
+		}
+		followedByMe := len(followed) > 0
+		detail.FollowedByMe = &followedByMe
+	}
+	return detail, nil
+}
+
+func (s *ArtistService) Search(ctx context.Context, req dto.SearchRequest) (*dto.ArtistSearchResponse, error) {
+	resp, _, err := s.searchRanked(ctx, req)
+	return resp, err
+}
+
+// searchRanked is Search, also returning each result's ranking position.
+func (s *ArtistService) searchRanked(ctx context.Context, req dto.SearchRequest) (*dto.ArtistSearchResponse, []database.SearchKeyset, error) {
+	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
+		return nil, nil, err
+	}
+
+	after, err := s.searchService.DecodeSearchCursor(searchScopeArtists, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, err := s.store.SearchArtists(ctx, req.Q, after, req.MaxResults)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := s.BuildSearchResults(page.Items)
+	if req.ViewerID != 0 {
+		if err := s.markFollowed(ctx, req.ViewerID, results); err != nil {
+			return nil, nil, err
+		}
+	}
+	meta, err := s.searchService.BuildSearchMeta(searchScopeArtists, req, len(results), page.Next)
+	if err != nil {
+		return nil, nil, err
+	}
 	return &dto.ArtistSearchResponse{
 		Results: results,
 		Meta:    meta,
-	}, nil
+	}, page.Keys, nil
 }
 
 // BuildSearchTemplateResponse prepares the search-card response contract.
@@ -53,3 +110,204 @@ func (s *ArtistService) BuildSearchResults(artists []models.Artist) []dto.Artist
 
 	return results
 }
+
+// ListConcerts returns the artist's concerts split into upcoming (soonest first) and past (latest first).
+// A cursor continues only the section it was issued for.
+func (s *ArtistService) ListConcerts(ctx context.Context, artistID int, req dto.ArtistConcertsRequest) (*dto.ArtistConcertsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.ensureExists(ctx, artistID); err != nil {
+		return nil, err
+	}
+
+	var after *artistConcertCursor
+	if req.Cursor != "" {
+		after = &artistConcertCursor{}
+		scope := PageScope{Name: pageScopeArtistConcerts, EntityID: artistID}
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, after); err != nil {
+			return nil, err
+		}
+		if after.When != dto.ArtistConcertsUpcoming && after.When != dto.ArtistConcertsPast {
+			return nil, apperr.ErrInvalidCursor
+		}
+		req.When = after.When
+	}
+
+	response := &dto.ArtistConcertsResponse{}
+	if req.When == "" || req.When == dto.ArtistConcertsUpcoming {
+		page, err := s.concertPage(ctx, artistID, dto.ArtistConcertsUpcoming, after, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		response.Upcoming = page
+	}
+	if req.When == "" || req.When == dto.ArtistConcertsPast {
+		page, err := s.concertPage(ctx, artistID, dto.ArtistConcertsPast, after, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+		response.Past = page
+	}
+	return response, nil
+}
+
+func (s *ArtistService) concertPage(ctx context.Context, artistID int, when string, after *artistConcertCursor, limit int) (*dto.ConcertPage, error) {
+	var afterDate *time.Time
+	var afterID int
+	if after != nil {
+		afterDate, afterID = &after.Date, after.ID
+	}
+
+	concerts, err := s.store.ListConcertsByArtist(ctx, artistID, when == dto.ArtistConcertsUpcoming, afterDate, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	concerts, hasMore := trimPage(concerts, limit)
+
+	results, err := s.concertService.BuildCards(ctx, concerts)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *artistConcertCursor
+	if len(concerts) > 0 {
+		c := concerts[len(concerts)-1]
+		last = &artistConcertCursor{When: when, Date: c.Date, ID: c.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(PageScope{Name: pageScopeArtistConcerts, EntityID: artistID}, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConcertPage{Results: results, Meta: meta}, nil
+}
+
+// ListSongs returns songs the artist has played live (or is credited with), most-performed first.
+func (s *ArtistService) ListSongs(ctx context.Context, artistID int, req dto.PageRequest) (*dto.ArtistSongsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.ensureExists(ctx, artistID); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeArtistSongs, EntityID: artistID}
+	var afterCount *int
+	var afterID int
+	if req.Cursor != "" {
+		var after artistSongCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCount, afterID = &after.TimesPerformed, after.ID
+	}
+
+	songs, err := s.store.ListSongsByArtist(ctx, artistID, afterCount, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	songs, hasMore := trimPage(songs, req.Limit)
+
+	results := make([]dto.ArtistSongItem, 0, len(songs))
+	for _, song := range songs {
+		results = append(results, dto.ArtistSongItem{
+			ID:              song.ID,
+			Title:           song.Title,
+			DurationSeconds: song.DurationSeconds,
+			IsVerified:      song.IsVerified,
+			IsCover:         song.ArtistID != nil && *song.ArtistID != artistID,
+			TimesPerformed:  song.TimesPerformed,
+		})
+	}
+
+	var last *artistSongCursor
+	if len(songs) > 0 {
+		song := songs[len(songs)-1]
+		last = &artistSongCursor{TimesPerformed: song.TimesPerformed, ID: song.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ArtistSongsResponse{Results: results, Meta: meta}, nil
+}
+
+// ListVideos returns public videos of the artist, newest first — including untagged
+// videos at concerts where the artist was the only act. viewerID (0 when anonymous) gets their own reactions.
+func (s *ArtistService) ListVideos(ctx context.Context, artistID int, viewerID int, req dto.PageRequest) (*dto.ArtistVideosResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.ensureExists(ctx, artistID); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeArtistVideos, EntityID: artistID}
+	var afterCreatedAt *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after videoCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
+
+	videos, err := s.store.ListVideosByArtist(ctx, artistID, afterCreatedAt, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	videos, hasMore := trimPage(videos, req.Limit)
+	if videos == nil {
+		videos = []*models.Video{}
+	}
+	if err := s.reactionService.Attach(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	if err := s.locationPrivacy.Apply(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+
+	var last *videoCursor
+	if len(videos) > 0 {
+		v := videos[len(videos)-1]
+		last = &videoCursor{CreatedAt: v.CreatedAt, ID: v.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ArtistVideosResponse{Results: videos, Meta: meta}, nil
+}
+
+// markFollowed sets FollowedByMe on each result for an authenticated viewer.
+func (s *ArtistService) markFollowed(ctx context.Context, viewerID int, results []dto.ArtistSearchItem) error {
+	ids := make([]int, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	followedIDs, err := s.store.ListFollowedArtistIDs(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+	followed := make(map[int]bool, len(followedIDs))
+	for _, id := range followedIDs {
+		followed[id] = true
+	}
+	for i := range results {
+		followedByMe := followed[results[i].ID]
+		results[i].FollowedByMe = &followedByMe
+	}
+	return nil
+}
+
+func (s *ArtistService) ensureExists(ctx context.Context, artistID int) error {
+	exists, err := s.store.ArtistExists(ctx, artistID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
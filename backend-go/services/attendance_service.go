@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// AttendanceService owns "I was there" records: explicit marks from users and
+// automatic records from uploads and confirmed detections.
+type AttendanceService struct {
+	store          *database.Store
+	searchService  *SearchService
+	concertService *ConcertService
+}
+
+func NewAttendanceService(store *database.Store, searchService *SearchService, concertService *ConcertService) *AttendanceService {
+	return &AttendanceService{store: store, searchService: searchService, concertService: concertService}
+}
+
+// attendeeCursor is the keyset position encoded into /concerts/:id/attendees cursors.
+type attendeeCursor struct {
+	CreatedAt time.Time `json:"c"`
+	UserID    int       `json:"id"`
+}
+
+// attendedConcertCursor is the keyset position encoded into user concert list cursors.
+type attendedConcertCursor struct {
+	Date time.Time `json:"d"`
+	ID   int       `json:"id"`
+}
+
+// Mark records that userID attended concertID. visibility is optional.
+func (s *AttendanceService) Mark(ctx context.Context, userID int, concertID int, visibility *string) (*models.Attendance, error) {
+	if err := s.ensureConcertExists(ctx, concertID); err != nil {
+		return nil, err
+	}
+	return s.store.UpsertAttendance(ctx, userID, concertID, models.AttendanceSourceManual, visibility)
+}
+
+// Unmark removes userID's attendance at concertID. It is idempotent.
+func (s *AttendanceService) Unmark(ctx context.Context, userID int, concertID int) error {
+	return s.store.DeleteAttendance(ctx, userID, concertID)
+}
+
+// Record creates an attendance record from an automatic source, keeping any existing record as-is.
+func (s *AttendanceService) Record(ctx context.Context, userID int, concertID int, source string) error {
+	return s.store.RecordAttendance(ctx, userID, concertID, source)
+}
+
+// ListAttendees pages through a concert's attendees. viewerID (0 when anonymous)
+// also sees their own record if it is private.
+func (s *AttendanceService) ListAttendees(ctx context.Context, concertID int, viewerID int, req dto.PageRequest) (*dto.ConcertAttendeesResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	if err := s.ensureConcertExists(ctx, concertID); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeConcertAttendees, EntityID: concertID}
+	var afterCreatedAt *time.Time
+	var afterUserID int
+	if req.Cursor != "" {
+		var after attendeeCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterUserID = &after.CreatedAt, after.UserID
+	}
+
+	attendees, err := s.store.ListAttendeesByConcert(ctx, concertID, viewerID, afterCreatedAt, afterUserID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	attendees, hasMore := trimPage(attendees, req.Limit)
+
+	results := make([]dto.ConcertAttendee, 0, len(attendees))
+	for _, a := range attendees {
+		results = append(results, dto.ConcertAttendee{
+			ID:                a.ID,
+			Username:          a.Username,
+			DisplayName:       a.DisplayName,
+			ProfilePictureURL: a.ProfilePictureURL,
+			AttendedAt:        a.AttendedAt,
+		})
+	}
+
+	var last *attendeeCursor
+	if len(attendees) > 0 {
+		a := attendees[len(attendees)-1]
+		last = &attendeeCursor{CreatedAt: a.AttendedAt, UserID: a.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConcertAttendeesResponse{Results: results, Meta: meta}, nil
+}
+
+// ListUserConcerts pages through the concerts a user attended, most recent first.
+// includePrivate is for the user viewing their own list.
+func (s *AttendanceService) ListUserConcerts(ctx context.Context, userID int, includePrivate bool, req dto.PageRequest) (*dto.ConcertPage, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeUserConcerts, EntityID: userID}
+	var afterDate *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after attendedConcertCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterDate, afterID = &after.Date, after.ID
+	}
+
+	concerts, err := s.store.ListAttendedConcertsByUser(ctx, userID, includePrivate, afterDate, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	concerts, hasMore := trimPage(concerts, req.Limit)
+
+	results, err := s.concertService.BuildCards(ctx, concerts)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *attendedConcertCursor
+	if len(concerts) > 0 {
+		c := concerts[len(concerts)-1]
+		last = &attendedConcertCursor{Date: c.Date, ID: c.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConcertPage{Results: results, Meta: meta}, nil
+}
+
+// ListUserConcertsByUsername is ListUserConcerts for a public profile: private records are hidden.
+func (s *AttendanceService) ListUserConcertsByUsername(ctx context.Context, username string, req dto.PageRequest) (*dto.ConcertPage, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListUserConcerts(ctx, user.ID, false, req)
+}
+
+func (s *AttendanceService) ensureConcertExists(ctx context.Context, concertID int) error {
+	exists, err := s.store.ConcertExists(ctx, concertID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
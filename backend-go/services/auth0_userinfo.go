@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const auth0UserInfoTimeout = 5 * time.Second
+
+// Auth0UserInfo asks Auth0 what it knows about the caller of an access token.
+// Emails in the /users/sync body are whatever the client says; only the email
+// Auth0 reports as verified is trusted for linking accounts.
+type Auth0UserInfo struct {
+	url    string
+	client *http.Client
+}
+
+// ProviderProfile is the part of the Auth0 /userinfo response we use.
+type ProviderProfile struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+func NewAuth0UserInfo(domain string) *Auth0UserInfo {
+	return &Auth0UserInfo{
+		url:    "https://" + domain + "/userinfo",
+		client: &http.Client{Timeout: auth0UserInfoTimeout},
+	}
+}
+
+// Fetch calls /userinfo with the caller's access token. The token must carry the
+// openid scope (and email, for the email claims).
+func (a *Auth0UserInfo) Fetch(ctx context.Context, accessToken string) (*ProviderProfile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth0 userinfo: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth0 userinfo: unexpected status %d", resp.StatusCode)
+	}
+
+	var profile ProviderProfile
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("auth0 userinfo: %w", err)
+	}
+	return &profile, nil
+}
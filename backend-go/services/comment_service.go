@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// maxCommentMentions caps how many @mentions one comment resolves; the rest stay plain text.
+const maxCommentMentions = 10
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_.\-]+)`)
+
+// CommentService owns comment threads on videos and concerts.
+type CommentService struct {
+	store               *database.Store
+	searchService       *SearchService
+	notificationService *NotificationService
+}
+
+func NewCommentService(store *database.Store, searchService *SearchService, notificationService *NotificationService) *CommentService {
+	return &CommentService{store: store, searchService: searchService, notificationService: notificationService}
+}
+
+// commentCursor is the keyset position of the last comment on a page.
+type commentCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+// List pages through a video's or concert's top-level comments, newest first.
+// shareToken opens an unlisted video's comments ("" when the viewer has none).
+func (s *CommentService) List(ctx context.Context, targetType string, targetID int, viewerID int, shareToken string, req dto.PageRequest) (*dto.CommentsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	if _, _, err := s.targetOwner(ctx, targetType, targetID, viewerID, shareToken); err != nil {
+		return nil, err
+	}
+	scope := PageScope{Name: pageScopeComments, EntityID: targetID, Query: targetType}
+	after, afterID, err := s.decodeCommentCursor(scope, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.store.ListComments(ctx, targetType, targetID, viewerID, after, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, scope, comments, req.Limit)
+}
+
+// ListReplies pages through a top-level comment's replies, oldest first.
+func (s *CommentService) ListReplies(ctx context.Context, commentID int, viewerID int, shareToken string, req dto.PageRequest) (*dto.CommentsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	parent, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.ParentID != nil || (parent.DeletedAt != nil && parent.ReplyCount == 0) {
+		return nil, apperr.ErrNotFound
+	}
+	if _, _, err := s.targetOwner(ctx, parent.TargetType, parent.TargetID, viewerID, shareToken); err != nil {
+		return nil, err
+	}
+	scope := PageScope{Name: pageScopeCommentReplies, EntityID: commentID}
+	after, afterID, err := s.decodeCommentCursor(scope, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	replies, err := s.store.ListReplies(ctx, commentID, viewerID, after, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return s.page(ctx, scope, replies, req.Limit)
+}
+
+// Create posts a comment, or a reply when req.ParentID is set. Users blocked either
+// way with the video's owner or the parent comment's author get apperr.ErrForbidden.
+func (s *CommentService) Create(ctx context.Context, userID int, targetType string, targetID int, shareToken string, req dto.CreateCommentRequest) (*dto.CommentItem, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, apperr.ErrEmptyComment
+	}
+
+	ownerID, public, err := s.targetOwner(ctx, targetType, targetID, userID, shareToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureNotBlocked(ctx, userID, ownerID); err != nil {
+		return nil, err
+	}
+
+	var parent *models.Comment
+	var parentID *int
+	if req.ParentID != nil {
+		parent, err = s.threadParent(ctx, *req.ParentID, targetType, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.ensureNotBlocked(ctx, userID, parent.UserID); err != nil {
+			return nil, err
+		}
+		parentID = &parent.ID
+	}
+
+	mentionIDs, err := s.resolveMentions(ctx, userID, body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := s.store.CreateComment(ctx, userID, targetType, targetID, parentID, body, mentionIDs)
+	if err != nil {
+		return nil, err
+	}
+	if !public {
+		// mentioned users couldn't open the video, so don't point them at it
+		mentionIDs = nil
+	}
+	s.notifyComment(ctx, comment, ownerID, parent, mentionIDs)
+	return s.buildOne(ctx, comment)
+}
+
+// notifyComment tells the people a new comment concerns, once each and most specific
+// first: mentioned users, then the parent comment's author, then the video's owner.
+func (s *CommentService) notifyComment(ctx context.Context, comment *models.Comment, ownerID int, parent *models.Comment, mentionIDs []int) {
+	notified := map[int]bool{comment.UserID: true}
+	notify := func(userID int, notificationType string) {
+		if userID == 0 || notified[userID] {
+			return
+		}
+		notified[userID] = true
+
+		n := models.Notification{
+			UserID:    userID,
+			Type:      notificationType,
+			ActorID:   &comment.UserID,
+			CommentID: &comment.ID,
+		}
+		switch comment.TargetType {
+		case models.CommentTargetVideo:
+			n.VideoID = &comment.TargetID
+		case models.CommentTargetConcert:
+			n.ConcertID = &comment.TargetID
+		}
+		s.notificationService.Notify(ctx, n)
+	}
+
+	for _, id := range mentionIDs {
+		notify(id, models.NotificationMention)
+	}
+	if parent != nil {
+		notify(parent.UserID, models.NotificationCommentReply)
+	}
+	notify(ownerID, models.NotificationComment)
+}
+
+// Update replaces the body of the user's own comment, re-resolving its mentions.
+func (s *CommentService) Update(ctx context.Context, userID int, commentID int, body string) (*dto.CommentItem, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, apperr.ErrEmptyComment
+	}
+
+	comment, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.DeletedAt != nil {
+		return nil, apperr.ErrNotFound
+	}
+	if comment.UserID != userID {
+		return nil, apperr.ErrForbidden
+	}
+
+	mentionIDs, err := s.resolveMentions(ctx, userID, body)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err = s.store.UpdateCommentBody(ctx, commentID, body, mentionIDs)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildOne(ctx, comment)
+}
+
+// Delete soft-deletes the user's own comment. Its replies stay visible under a placeholder.
+func (s *CommentService) Delete(ctx context.Context, userID int, commentID int) error {
+	comment, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.DeletedAt != nil {
+		return apperr.ErrNotFound
+	}
+	if comment.UserID != userID {
+		return apperr.ErrForbidden
+	}
+	return s.store.SoftDeleteComment(ctx, commentID)
+}
+
+// Report queues a comment the reporter can see for moderation.
+// Reporting the same comment twice returns apperr.ErrDuplicate.
+func (s *CommentService) Report(ctx context.Context, reporterID int, commentID int, shareToken string, req dto.ReportCommentRequest) (*models.CommentReport, error) {
+	comment, err := s.store.GetCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.DeletedAt != nil {
+		return nil, apperr.ErrNotFound
+	}
+	if _, _, err := s.targetOwner(ctx, comment.TargetType, comment.TargetID, reporterID, shareToken); err != nil {
+		return nil, err
+	}
+	return s.store.CreateCommentReport(ctx, commentID, reporterID, req.Reason, req.Details)
+}
+
+// targetOwner checks that the comment target exists and viewerID may see it, and
+// returns the user who owns it (the video's uploader; 0 for concerts) and whether
+// everyone may see it. shareToken opens unlisted videos, as on GET /videos/:id.
+func (s *CommentService) targetOwner(ctx context.Context, targetType string, targetID int, viewerID int, shareToken string) (int, bool, error) {
+	switch targetType {
+	case models.CommentTargetVideo:
+		video, err := s.store.GetVideoByID(ctx, targetID)
+		if err != nil {
+			return 0, false, err
+		}
+		if !videoVisibleTo(video, viewerID, shareToken) {
+			return 0, false, apperr.ErrNotFound
+		}
+		return video.UserID, videoVisibleTo(video, 0, ""), nil
+	case models.CommentTargetConcert:
+		exists, err := s.store.ConcertExists(ctx, targetID)
+		if err != nil {
+			return 0, false, err
+		}
+		if !exists {
+			return 0, false, apperr.ErrNotFound
+		}
+		return 0, true, nil
+	}
+	return 0, false, apperr.ErrNotFound
+}
+
+// threadParent returns the top-level comment a reply to parentID belongs under.
+// Replying to a reply joins its thread, keeping threads one level deep.
+func (s *CommentService) threadParent(ctx context.Context, parentID int, targetType string, targetID int) (*models.Comment, error) {
+	parent, err := s.store.GetCommentByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.TargetType != targetType || parent.TargetID != targetID || parent.DeletedAt != nil {
+		return nil, apperr.ErrNotFound
+	}
+	if parent.ParentID == nil {
+		return parent, nil
+	}
+
+	top, err := s.store.GetCommentByID(ctx, *parent.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	if top.DeletedAt != nil && top.ReplyCount == 0 {
+		return nil, apperr.ErrNotFound
+	}
+	return top, nil
+}
+
+// ensureNotBlocked returns apperr.ErrForbidden when userID and otherID have blocked each other.
+func (s *CommentService) ensureNotBlocked(ctx context.Context, userID int, otherID int) error {
+	if otherID == 0 || otherID == userID {
+		return nil
+	}
+	blocked, err := s.store.IsBlockedEitherWay(ctx, userID, otherID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return apperr.ErrForbidden
+	}
+	return nil
+}
+
+// resolveMentions maps @username mentions in body to user IDs. Unknown usernames,
+// the author themself and users blocked either way with the author are skipped.
+func (s *CommentService) resolveMentions(ctx context.Context, authorID int, body string) ([]int, error) {
+	seen := make(map[string]bool)
+	var usernames []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		// usernames ignore case, so @DKang and @dkang are the same mention
+		username := strings.ToLower(strings.TrimRight(match[1], ".-"))
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+		if len(usernames) == maxCommentMentions {
+			break
+		}
+	}
+	if len(usernames) == 0 {
+		return []int{}, nil
+	}
+
+	users, err := s.store.ListUsersByUsernames(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		if u.ID != authorID {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	blockedIDs, err := s.store.ListBlockedEitherWayIDs(ctx, authorID, ids)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(map[int]bool, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+
+	mentionIDs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !blocked[id] {
+			mentionIDs = append(mentionIDs, id)
+		}
+	}
+	return mentionIDs, nil
+}
+
+func (s *CommentService) page(ctx context.Context, scope PageScope, comments []models.Comment, limit int) (*dto.CommentsResponse, error) {
+	comments, hasMore := trimPage(comments, limit)
+	results, err := s.buildItems(ctx, comments)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *commentCursor
+	if len(comments) > 0 {
+		c := comments[len(comments)-1]
+		last = &commentCursor{CreatedAt: c.CreatedAt, ID: c.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CommentsResponse{Results: results, Meta: meta}, nil
+}
+
+func (s *CommentService) buildOne(ctx context.Context, comment *models.Comment) (*dto.CommentItem, error) {
+	items, err := s.buildItems(ctx, []models.Comment{*comment})
+	if err != nil {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// buildItems loads authors and mentions for comments and renders them, blanking deleted ones.
+func (s *CommentService) buildItems(ctx context.Context, comments []models.Comment) ([]dto.CommentItem, error) {
+	authorIDs := make([]int, 0, len(comments))
+	commentIDs := make([]int, 0, len(comments))
+	for _, c := range comments {
+		if c.DeletedAt == nil {
+			authorIDs = append(authorIDs, c.UserID)
+			commentIDs = append(commentIDs, c.ID)
+		}
+	}
+
+	authors, err := s.store.ListUsersByIDs(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorsByID := indexBy(authors, func(u models.User) int { return u.ID })
+
+	mentions, err := s.store.ListCommentMentions(ctx, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.CommentItem, 0, len(comments))
+	for _, c := range comments {
+		item := dto.CommentItem{
+			ID:         c.ID,
+			ParentID:   c.ParentID,
+			Mentions:   []dto.UserCompact{},
+			ReplyCount: c.ReplyCount,
+			Deleted:    c.DeletedAt != nil,
+			CreatedAt:  c.CreatedAt,
+		}
+		if !item.Deleted {
+			if author, ok := authorsByID[c.UserID]; ok {
+				item.Author = userCompact(author)
+			}
+			item.Body = c.Body
+			item.EditedAt = c.EditedAt
+			for _, u := range mentions[c.ID] {
+				item.Mentions = append(item.Mentions, *userCompact(u))
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (s *CommentService) decodeCommentCursor(scope PageScope, cursor string) (*time.Time, int, error) {
+	if cursor == "" {
+		return nil, 0, nil
+	}
+	var after commentCursor
+	if err := s.searchService.DecodePageCursor(scope, cursor, &after); err != nil {
+		return nil, 0, err
+	}
+	return &after.CreatedAt, after.ID, nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
@@ -25,15 +26,160 @@ func (s *ConcertService) Get(ctx context.Context, concertID int) (*models.Concer
 }
 
 func (s *ConcertService) Search(ctx context.Context, req dto.SearchRequest) (*dto.ConcertSearchResponse, error) {
+	resp, _, err := s.searchRanked(ctx, req)
+	return resp, err
+}
+
+// searchRanked is Search, also returning each result's ranking position.
+func (s *ConcertService) searchRanked(ctx context.Context, req dto.SearchRequest) (*dto.ConcertSearchResponse, []database.SearchKeyset, error) {
 	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
+		return nil, nil, err
+	}
+
+	after, err := s.searchService.DecodeSearchCursor(searchScopeConcerts, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page, err := s.store.SearchConcerts(ctx, req.Q, concertSearchFilter(req.ConcertFilters), after, req.MaxResults)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results, err := s.BuildCards(ctx, page.Items)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := s.searchService.BuildSearchMeta(searchScopeConcerts, req, len(results), page.Next)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &dto.ConcertSearchResponse{
+		Results: results,
+		Meta:    meta,
+	}, page.Keys, nil
+}
+
+// Calendar returns a month of concerts grouped by day.
+// userID is required when req.Only is set and ignored otherwise.
+func (s *ConcertService) Calendar(ctx context.Context, req dto.ConcertCalendarRequest, userID int) (*dto.ConcertCalendarResponse, error) {
+	var attendedBy, followedBy *int
+	switch req.Only {
+	case dto.CalendarOnlyAttended:
+		attendedBy = &userID
+	case dto.CalendarOnlyFollowed:
+		followedBy = &userID
+	}
+
+	from := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	entries, err := s.store.ListCalendarConcerts(ctx, from, to, attendedBy, followedBy, dto.CalendarConcertsPerDayMax)
+	if err != nil {
 		return nil, err
 	}
 
-	concerts, err := s.store.SearchConcerts(ctx, req.Q, req.MaxResults)
+	concerts := make([]models.Concert, 0, len(entries))
+	concertIDs := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		concerts = append(concerts, entry.Concert)
+		concertIDs = append(concertIDs, entry.ID)
+	}
+
+	acts, err := s.store.ListActsByConcertIDs(ctx, concertIDs)
 	if err != nil {
 		return nil, err
 	}
+	actsByConcert := make(map[int][]models.Act, len(concertIDs))
+	for _, act := range acts {
+		actsByConcert[act.ConcertID] = append(actsByConcert[act.ConcertID], act)
+	}
 
+	artistIDs, venueIDs := collectConcertRelationIDs(concerts)
+	for _, act := range acts {
+		artistIDs = append(artistIDs, act.ArtistID)
+	}
+	artists, err := s.store.ListArtistsByIDs(ctx, artistIDs)
+	if err != nil {
+		return nil, err
+	}
+	artistsByID := indexBy(artists, func(a models.Artist) int { return a.ID })
+	venues, err := s.store.ListVenuesByIDs(ctx, venueIDs)
+	if err != nil {
+		return nil, err
+	}
+	venuesByID := indexBy(venues, func(v models.Venue) int { return v.ID })
+
+	days := make([]dto.CalendarDay, 0)
+	for _, entry := range entries {
+		day := entry.Date.Format(time.DateOnly)
+		if len(days) == 0 || days[len(days)-1].Date != day {
+			days = append(days, dto.CalendarDay{
+				Date:         day,
+				ConcertCount: entry.DayConcertCount,
+				Concerts:     make([]dto.CalendarConcert, 0),
+			})
+		}
+
+		item := dto.CalendarConcert{
+			ID:      entry.ID,
+			Name:    entry.Name,
+			Date:    entry.Date,
+			Artists: calendarArtists(entry.Concert, actsByConcert[entry.ID], artistsByID),
+		}
+		if entry.VenueID != nil {
+			if venue, ok := venuesByID[*entry.VenueID]; ok {
+				item.Venue = &dto.VenueCompact{
+					ID:          venue.ID,
+					Name:        venue.Name,
+					City:        venue.City,
+					Region:      venue.Region,
+					CountryCode: venue.CountryCode,
+				}
+			}
+		}
+		days[len(days)-1].Concerts = append(days[len(days)-1].Concerts, item)
+	}
+
+	return &dto.ConcertCalendarResponse{
+		Year:  req.Year,
+		Month: req.Month,
+		Days:  days,
+	}, nil
+}
+
+// calendarArtists lists a concert's performers from its acts, with the primary
+// artist (concerts.artist_id, when set) first and the rest in act order.
+func calendarArtists(concert models.Concert, acts []models.Act, artistsByID map[int]models.Artist) []dto.ArtistCompact {
+	out := make([]dto.ArtistCompact, 0, len(acts))
+	seen := make(map[int]struct{}, len(acts))
+	add := func(id int) {
+		if _, ok := seen[id]; ok {
+			return
+		}
+		artist, ok := artistsByID[id]
+		if !ok {
+			return
+		}
+		seen[id] = struct{}{}
+		out = append(out, dto.ArtistCompact{
+			ID:       artist.ID,
+			Name:     artist.Name,
+			ImageURL: artist.ImageURL,
+		})
+	}
+
+	if concert.ArtistID != nil {
+		add(*concert.ArtistID)
+	}
+	for _, act := range acts {
+		add(act.ArtistID)
+	}
+	return out
+}
+
+// BuildCards loads the primary artists and venues for concerts and renders them as cards.
+// Shared by search and every other endpoint that lists concerts (artist pages, calendars, ...).
+func (s *ConcertService) BuildCards(ctx context.Context, concerts []models.Concert) ([]dto.ConcertSearchItem, error) {
 	artistIDs, venueIDs := collectConcertRelationIDs(concerts)
 
 	artists, err := s.store.ListArtistsByIDs(ctx, artistIDs)
@@ -48,16 +194,7 @@ func (s *ConcertService) Search(ctx context.Context, req dto.SearchRequest) (*dt
 	}
 	venuesByID := indexBy(venues, func(v models.Venue) int { return v.ID })
 
-	results := s.BuildSearchResults(
-		concerts,
-		artistsByID,
-		venuesByID,
-	)
-	meta := s.searchService.BuildSearchMeta(req, len(results))
-	return &dto.ConcertSearchResponse{
-		Results: results,
-		Meta:    meta,
-	}, nil
+	return s.BuildSearchResults(concerts, artistsByID, venuesByID), nil
 }
 
 func (s *ConcertService) BuildSearchResults(
@@ -132,3 +269,18 @@ func collectConcertRelationIDs(concerts []models.Concert) ([]int, []int) {
 
 	return artistIDs, venueIDs
 }
+
+// concertSearchFilter converts request filters to the store's filter. nil means unfiltered.
+func concertSearchFilter(f *dto.ConcertSearchFilters) database.ConcertSearchFilter {
+	if f == nil {
+		return database.ConcertSearchFilter{}
+	}
+	return database.ConcertSearchFilter{
+		DateFrom:    f.DateFrom,
+		DateTo:      f.DateTo,
+		City:        f.City,
+		CountryCode: f.CountryCode,
+		ArtistID:    f.ArtistID,
+		HasVideos:   f.HasVideos,
+	}
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const (
+	dataExportListLimit   = 20  // exports shown by GET /users/me/exports
+	dataExportExpireBatch = 100 // expired archives deleted per cleanup pass
+)
+
+// DataExportService builds personal data archives: a ZIP of JSON files covering the
+// user's profile, videos (with links to the original uploads), attendance, comments
+// and reactions. Archives are stored privately and handed out through presigned links
+// that stop working when the archive expires.
+type DataExportService struct {
+	store         *database.Store
+	upload        *UploadService
+	notifications *NotificationService
+	ttl           time.Duration
+}
+
+func NewDataExportService(store *database.Store, upload *UploadService, notifications *NotificationService, ttl time.Duration) *DataExportService {
+	return &DataExportService{store: store, upload: upload, notifications: notifications, ttl: ttl}
+}
+
+// Request queues an export for userID. Returns ErrDuplicate while one is already in flight.
+func (s *DataExportService) Request(ctx context.Context, userID int) (*models.DataExport, error) {
+	return s.store.CreateDataExport(ctx, userID)
+}
+
+// List returns userID's recent exports, newest first, with download links for completed ones.
+func (s *DataExportService) List(ctx context.Context, userID int) (*dto.DataExportsResponse, error) {
+	exports, err := s.store.ListDataExports(ctx, userID, dataExportListLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, export := range exports {
+		if err := s.sign(ctx, export); err != nil {
+			return nil, err
+		}
+	}
+	return &dto.DataExportsResponse{Results: exports}, nil
+}
+
+// Get returns one of userID's exports, with a download link once it is completed.
+func (s *DataExportService) Get(ctx context.Context, userID int, exportID int) (*models.DataExport, error) {
+	export, err := s.store.GetDataExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sign(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Build writes the archive for a claimed export, uploads it, and notifies the user
+// with a download link.
+func (s *DataExportService) Build(ctx context.Context, export *models.DataExport) error {
+	expiresAt := time.Now().Add(s.ttl)
+	archive, err := s.buildArchive(ctx, export.UserID, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	key := dataExportKey(export.UserID, export.ID)
+	if _, err := s.upload.PutObject(ctx, key, archive, "application/zip", false); err != nil {
+		return err
+	}
+	if err := s.store.SetDataExportCompleted(ctx, export.ID, key, int64(len(archive)), expiresAt); err != nil {
+		return fmt.Errorf("failed to mark export completed: %w", err)
+	}
+
+	url, err := s.upload.PresignGet(ctx, key, time.Until(expiresAt))
+	if err != nil {
+		return err
+	}
+	s.notifications.Notify(ctx, models.Notification{
+		UserID: export.UserID,
+		Type:   models.NotificationDataExportReady,
+		Data: map[string]any{
+			"export_id":    export.ID,
+			"download_url": url,
+			"expires_at":   expiresAt,
+		},
+	})
+	return nil
+}
+
+// Fail marks an export failed and tells the user.
+func (s *DataExportService) Fail(ctx context.Context, export *models.DataExport) {
+	if err := s.store.SetDataExportFailed(ctx, export.ID); err != nil {
+		log.Printf("[data-export] export %d: failed to mark as failed: %v", export.ID, err)
+	}
+	s.notifications.Notify(ctx, models.Notification{
+		UserID: export.UserID,
+		Type:   models.NotificationDataExportFailed,
+		Data:   map[string]any{"export_id": export.ID},
+	})
+}
+
+// DeleteExpired removes expired archives from storage.
+func (s *DataExportService) DeleteExpired(ctx context.Context) error {
+	exports, err := s.store.ListExpiredDataExports(ctx, dataExportExpireBatch)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.S3Key != nil {
+			if err := s.upload.DeleteObject(ctx, *export.S3Key); err != nil {
+				return err
+			}
+		}
+		if err := s.store.SetDataExportExpired(ctx, export.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sign sets DownloadURL on a completed export, valid until the archive expires.
+func (s *DataExportService) sign(ctx context.Context, export *models.DataExport) error {
+	if export.Status != models.DataExportStatusCompleted || export.S3Key == nil || export.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(*export.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	url, err := s.upload.PresignGet(ctx, *export.S3Key, ttl)
+	if err != nil {
+		return err
+	}
+	export.DownloadURL = &url
+	return nil
+}
+
+// buildArchive collects the user's data and zips it up with a manifest. Video links
+// are presigned to stop working when the archive expires.
+func (s *DataExportService) buildArchive(ctx context.Context, userID int, expiresAt time.Time) ([]byte, error) {
+	user, err := s.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	prefs, err := s.store.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+	videos, err := s.store.ListAllUserVideos(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load videos: %w", err)
+	}
+	attendance, err := s.store.ListAttendanceByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load attendance: %w", err)
+	}
+	comments, err := s.store.ListCommentsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load comments: %w", err)
+	}
+	reactions, err := s.store.ListReactionsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reactions: %w", err)
+	}
+
+	exportVideos := make([]dto.DataExportVideo, 0, len(videos))
+	for _, v := range videos {
+		url, err := s.upload.PresignGet(ctx, v.S3Key, time.Until(expiresAt))
+		if err != nil {
+			return nil, err
+		}
+		exportVideos = append(exportVideos, dto.DataExportVideo{Video: *v, OriginalURL: url})
+	}
+
+	files := []struct {
+		file dto.DataExportFile
+		data any
+	}{
+		{dto.DataExportFile{Name: "profile.json", Description: "your profile and notification settings", Records: 1},
+			dto.DataExportProfile{User: *user, NotificationPreferences: expandNotificationPreferences(prefs)}},
+		{dto.DataExportFile{Name: "videos.json", Description: "your videos, with links to the original uploads", Records: len(exportVideos)},
+			exportVideos},
+		{dto.DataExportFile{Name: "attendance.json", Description: "concerts you marked as attended", Records: len(attendance)},
+			attendance},
+		{dto.DataExportFile{Name: "comments.json", Description: "comments you wrote", Records: len(comments)},
+			comments},
+		{dto.DataExportFile{Name: "reactions.json", Description: "your reactions to videos", Records: len(reactions)},
+			reactions},
+	}
+
+	manifest := dto.DataExportManifest{
+		UserID:      userID,
+		GeneratedAt: time.Now(),
+		LinksExpire: expiresAt,
+		Files:       make([]dto.DataExportFile, 0, len(files)),
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		if err := writeJSONEntry(zw, f.file.Name, f.data); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, f.file)
+	}
+	if err := writeJSONEntry(zw, "manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeJSONEntry(zw *zip.Writer, name string, data any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// dataExportKey is where a user's export archive is stored. exports/ is never public.
+func dataExportKey(userID int, exportID int) string {
+	return fmt.Sprintf("exports/%d/%d.zip", userID, exportID)
+}
@@ -6,23 +6,50 @@ import (
 
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
 )
 
 // DetectionService contains all detection logic when the client explicitly triggers a detect request
 type DetectionService struct {
-	store *database.Store
+	store         *database.Store
+	notifications *NotificationService
 }
 
-func NewDetectionService(store *database.Store) *DetectionService {
-	return &DetectionService{store: store}
+func NewDetectionService(store *database.Store, notifications *NotificationService) *DetectionService {
+	return &DetectionService{store: store, notifications: notifications}
 }
 
 // DetectConcert attempts to match the provided metadata to a concert.
-// Stateless — no DB writes. Returns top candidates ordered by confidence score.
+// No DB writes besides notifying userID when req.VideoID is one of their videos
+// with candidates to confirm. Returns top candidates ordered by confidence score.
 // Returns a non-nil error only for infrastructure failures (e.g. DB query failed).
-func (ds *DetectionService) DetectConcert(ctx context.Context, req dto.ConcertDetectRequest) (*dto.ConcertDetectResult, error) {
+func (ds *DetectionService) DetectConcert(ctx context.Context, userID int, req dto.ConcertDetectRequest) (*dto.ConcertDetectResult, error) {
 	// TODO: implement GPS + timestamp concert matching against concerts table.
 	log.Printf("[detection] detection not yet implemented")
 
-	return &dto.ConcertDetectResult{Detected: false, Matches: []dto.ConcertMatch{}}, nil
+	result := &dto.ConcertDetectResult{Detected: false, Matches: []dto.ConcertMatch{}}
+	if req.VideoID != nil && len(result.Matches) > 0 {
+		ds.notifyCandidates(ctx, userID, *req.VideoID, result.Matches)
+	}
+	return result, nil
+}
+
+// notifyCandidates tells the owner of videoID that it has concert candidates to confirm.
+// Videos that aren't userID's are ignored.
+func (ds *DetectionService) notifyCandidates(ctx context.Context, userID int, videoID int, matches []dto.ConcertMatch) {
+	video, err := ds.store.GetVideoByID(ctx, videoID)
+	if err != nil || video.UserID != userID {
+		return
+	}
+
+	concertIDs := make([]int, 0, len(matches))
+	for _, m := range matches {
+		concertIDs = append(concertIDs, m.Concert.ID)
+	}
+	ds.notifications.Notify(ctx, models.Notification{
+		UserID:  userID,
+		Type:    models.NotificationDetectionCandidates,
+		VideoID: &videoID,
+		Data:    map[string]any{"concert_ids": concertIDs},
+	})
 }
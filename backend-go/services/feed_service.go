@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// feedLookback bounds how far back the home feed looks for activity,
+// keeping the feed query cheap for users who follow a lot.
+const feedLookback = 90 * 24 * time.Hour
+
+// FeedService builds a signed-in user's home feed from their follows and attendance.
+type FeedService struct {
+	store           *database.Store
+	searchService   *SearchService
+	concertService  *ConcertService
+	reactionService *ReactionService
+	locationPrivacy *LocationPrivacyService
+}
+
+func NewFeedService(store *database.Store, searchService *SearchService, concertService *ConcertService, reactionService *ReactionService, locationPrivacy *LocationPrivacyService) *FeedService {
+	return &FeedService{store: store, searchService: searchService, concertService: concertService, reactionService: reactionService, locationPrivacy: locationPrivacy}
+}
+
+// feedCursor is the keyset position of the last feed entry on a page.
+type feedCursor struct {
+	ActivityAt time.Time `json:"t"`
+	ConcertID  *int      `json:"c,omitempty"`
+	VideoID    *int      `json:"v,omitempty"`
+}
+
+// Home returns one page of userID's home feed, newest activity first.
+func (s *FeedService) Home(ctx context.Context, userID int, req dto.PageRequest) (*dto.FeedResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeHomeFeed, EntityID: userID}
+	var after *models.FeedEntry
+	if req.Cursor != "" {
+		var c feedCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &c); err != nil {
+			return nil, err
+		}
+		after = &models.FeedEntry{ActivityAt: c.ActivityAt, ConcertID: c.ConcertID, VideoID: c.VideoID}
+	}
+
+	since := time.Now().Add(-feedLookback)
+	entries, err := s.store.ListFeedEntries(ctx, userID, since, after, dto.FeedVideoPreviewMax, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	entries, hasMore := trimPage(entries, req.Limit)
+
+	results, err := s.buildItems(ctx, userID, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *feedCursor
+	if len(entries) > 0 {
+		e := entries[len(entries)-1]
+		last = &feedCursor{ActivityAt: e.ActivityAt, ConcertID: e.ConcertID, VideoID: e.VideoID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FeedResponse{Results: results, Meta: meta}, nil
+}
+
+// buildItems loads the concerts and preview videos for a page of entries in two queries.
+// Entries whose concert or videos vanished since the page was read are dropped.
+func (s *FeedService) buildItems(ctx context.Context, viewerID int, entries []models.FeedEntry) ([]dto.FeedItem, error) {
+	var concertIDs, videoIDs []int
+	for _, e := range entries {
+		if e.ConcertID != nil {
+			concertIDs = append(concertIDs, *e.ConcertID)
+		}
+		videoIDs = append(videoIDs, e.VideoIDs...)
+	}
+
+	concerts, err := s.store.ListConcertsByIDs(ctx, concertIDs)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.concertService.BuildCards(ctx, concerts)
+	if err != nil {
+		return nil, err
+	}
+	cardsByID := indexBy(cards, func(c dto.ConcertSearchItem) int { return c.ID })
+
+	videos, err := s.store.ListPublicVideosByIDs(ctx, videoIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reactionService.Attach(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	if err := s.locationPrivacy.Apply(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	videosByID := indexBy(videos, func(v *models.Video) int { return v.ID })
+
+	items := make([]dto.FeedItem, 0, len(entries))
+	for _, e := range entries {
+		item := dto.FeedItem{
+			Type:       dto.FeedItemTypeVideo,
+			ActivityAt: e.ActivityAt,
+			Reasons:    e.Reasons,
+			VideoCount: e.VideoCount,
+			Videos:     make([]*models.Video, 0, len(e.VideoIDs)),
+		}
+		for _, id := range e.VideoIDs {
+			if v, ok := videosByID[id]; ok {
+				item.Videos = append(item.Videos, v)
+			}
+		}
+
+		if e.ConcertID != nil {
+			card, ok := cardsByID[*e.ConcertID]
+			if !ok {
+				continue
+			}
+			item.Type, item.Concert = dto.FeedItemTypeConcert, &card
+		} else if len(item.Videos) == 0 {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// FollowService owns the social graph: users following users, users following artists,
+// and users blocking users.
+type FollowService struct {
+	store               *database.Store
+	searchService       *SearchService
+	notificationService *NotificationService
+}
+
+func NewFollowService(store *database.Store, searchService *SearchService, notificationService *NotificationService) *FollowService {
+	return &FollowService{store: store, searchService: searchService, notificationService: notificationService}
+}
+
+// followCursor is the keyset position encoded into follower/following list cursors.
+type followCursor struct {
+	FollowedAt time.Time `json:"t"`
+	ID         int       `json:"id"`
+}
+
+// FollowUser makes followerID follow the user with username. Following is idempotent;
+// only a new follow notifies the followee. Users blocked either way can't follow each other.
+func (s *FollowService) FollowUser(ctx context.Context, followerID int, username string) error {
+	followee, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if followee.ID == followerID {
+		return apperr.ErrSelfFollow
+	}
+
+	blocked, err := s.store.IsBlockedEitherWay(ctx, followerID, followee.ID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return apperr.ErrForbidden
+	}
+
+	created, err := s.store.FollowUser(ctx, followerID, followee.ID)
+	if err != nil {
+		return err
+	}
+	if created {
+		s.notificationService.Notify(ctx, models.Notification{
+			UserID:  followee.ID,
+			Type:    models.NotificationFollow,
+			ActorID: &followerID,
+		})
+	}
+	return nil
+}
+
+// UnfollowUser removes followerID's follow of username. Unfollowing is idempotent.
+func (s *FollowService) UnfollowUser(ctx context.Context, followerID int, username string) error {
+	followee, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.store.UnfollowUser(ctx, followerID, followee.ID)
+}
+
+// FollowArtist makes userID follow artistID. Following is idempotent.
+func (s *FollowService) FollowArtist(ctx context.Context, userID int, artistID int) error {
+	exists, err := s.store.ArtistExists(ctx, artistID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.ErrNotFound
+	}
+	return s.store.FollowArtist(ctx, userID, artistID)
+}
+
+// UnfollowArtist removes userID's follow of artistID. Unfollowing is idempotent.
+func (s *FollowService) UnfollowArtist(ctx context.Context, userID int, artistID int) error {
+	return s.store.UnfollowArtist(ctx, userID, artistID)
+}
+
+// BlockUser makes blockerID block the user with username, dropping follows between them.
+// Blocking is idempotent.
+func (s *FollowService) BlockUser(ctx context.Context, blockerID int, username string) error {
+	blocked, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if blocked.ID == blockerID {
+		return apperr.ErrSelfBlock
+	}
+	return s.store.BlockUser(ctx, blockerID, blocked.ID)
+}
+
+// UnblockUser removes blockerID's block of username. Unblocking is idempotent.
+func (s *FollowService) UnblockUser(ctx context.Context, blockerID int, username string) error {
+	blocked, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	return s.store.UnblockUser(ctx, blockerID, blocked.ID)
+}
+
+// ListFollowers pages through the users following username, most recent first.
+func (s *FollowService) ListFollowers(ctx context.Context, username string, req dto.PageRequest) (*dto.FollowUsersResponse, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	scope := PageScope{Name: pageScopeUserFollowers, EntityID: user.ID}
+	return s.userPage(ctx, scope, req, func(after *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+		return s.store.ListUserFollowers(ctx, user.ID, after, afterID, limit)
+	})
+}
+
+// ListFollowingUsers pages through the users username follows, most recent first.
+func (s *FollowService) ListFollowingUsers(ctx context.Context, username string, req dto.PageRequest) (*dto.FollowUsersResponse, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	scope := PageScope{Name: pageScopeUserFollowing, EntityID: user.ID}
+	return s.userPage(ctx, scope, req, func(after *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+		return s.store.ListFollowingUsers(ctx, user.ID, after, afterID, limit)
+	})
+}
+
+// ListArtistFollowers pages through the users following an artist, most recent first.
+func (s *FollowService) ListArtistFollowers(ctx context.Context, artistID int, req dto.PageRequest) (*dto.FollowUsersResponse, error) {
+	exists, err := s.store.ArtistExists(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.ErrNotFound
+	}
+	scope := PageScope{Name: pageScopeArtistFollowers, EntityID: artistID}
+	return s.userPage(ctx, scope, req, func(after *time.Time, afterID int, limit int) ([]models.FollowedUser, error) {
+		return s.store.ListArtistFollowers(ctx, artistID, after, afterID, limit)
+	})
+}
+
+// ListFollowingArtists pages through the artists username follows, most recent first.
+func (s *FollowService) ListFollowingArtists(ctx context.Context, username string, req dto.PageRequest) (*dto.FollowArtistsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	scope := PageScope{Name: pageScopeFollowedArtists, EntityID: user.ID}
+	after, afterID, err := s.decodeFollowCursor(scope, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	artists, err := s.store.ListFollowingArtists(ctx, user.ID, after, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	artists, hasMore := trimPage(artists, req.Limit)
+
+	results := make([]dto.FollowArtistItem, 0, len(artists))
+	for _, a := range artists {
+		results = append(results, dto.FollowArtistItem{
+			ID:         a.ID,
+			Name:       a.Name,
+			ImageURL:   a.ImageURL,
+			IsVerified: a.IsVerified,
+			FollowedAt: a.FollowedAt,
+		})
+	}
+
+	var last *followCursor
+	if len(artists) > 0 {
+		a := artists[len(artists)-1]
+		last = &followCursor{FollowedAt: a.FollowedAt, ID: a.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FollowArtistsResponse{Results: results, Meta: meta}, nil
+}
+
+// userPage runs one page of a user follow list query and renders it.
+func (s *FollowService) userPage(
+	ctx context.Context,
+	scope PageScope,
+	req dto.PageRequest,
+	list func(after *time.Time, afterID int, limit int) ([]models.FollowedUser, error),
+) (*dto.FollowUsersResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	after, afterID, err := s.decodeFollowCursor(scope, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := list(after, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	users, hasMore := trimPage(users, req.Limit)
+
+	results := make([]dto.FollowUserItem, 0, len(users))
+	for _, u := range users {
+		results = append(results, dto.FollowUserItem{
+			ID:                u.ID,
+			Username:          u.Username,
+			DisplayName:       u.DisplayName,
+			ProfilePictureURL: u.ProfilePictureURL,
+			FollowedAt:        u.FollowedAt,
+		})
+	}
+
+	var last *followCursor
+	if len(users) > 0 {
+		u := users[len(users)-1]
+		last = &followCursor{FollowedAt: u.FollowedAt, ID: u.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FollowUsersResponse{Results: results, Meta: meta}, nil
+}
+
+func (s *FollowService) decodeFollowCursor(scope PageScope, cursor string) (*time.Time, int, error) {
+	if cursor == "" {
+		return nil, 0, nil
+	}
+	var after followCursor
+	if err := s.searchService.DecodePageCursor(scope, cursor, &after); err != nil {
+		return nil, 0, err
+	}
+	return &after.FollowedAt, after.ID, nil
+}
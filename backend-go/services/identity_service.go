@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const accountLinkCodeTTL = 10 * time.Minute
+
+// IdentityService maps Auth0 subjects onto accounts. One account can sign in with
+// several identities (Google, Apple, email/password…). A new identity joins an
+// existing account automatically when Auth0 reports the same verified email, and
+// otherwise through a one-time link code issued while signed in to that account.
+type IdentityService struct {
+	store    *database.Store
+	userInfo *Auth0UserInfo // nil when auth is bypassed in development
+}
+
+func NewIdentityService(store *database.Store, userInfo *Auth0UserInfo) *IdentityService {
+	return &IdentityService{store: store, userInfo: userInfo}
+}
+
+// Sync handles Auth0 login/signup for subject. Known identities refresh the
+// account's email, and its username until the user picks one in the app. New
+// identities join the account with the same verified email, or get a new account.
+func (s *IdentityService) Sync(ctx context.Context, subject string, accessToken string, req dto.SyncUserRequest) (*models.User, error) {
+	profile, fromProvider := s.providerProfile(ctx, accessToken, req.Email)
+
+	identity, err := s.store.GetUserIdentity(ctx, subject)
+	if err == nil {
+		return s.syncKnown(ctx, identity, profile, fromProvider, req)
+	}
+	if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+
+	if profile.EmailVerified {
+		user, err := s.store.FindUserByVerifiedEmail(ctx, profile.Email)
+		if err == nil {
+			if err := s.addIdentity(ctx, subject, user.ID, profile); err != nil {
+				return nil, err
+			}
+			return user, nil
+		}
+		if !errors.Is(err, apperr.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return s.createUser(ctx, subject, profile, req)
+}
+
+// syncKnown refreshes the account behind an identity that has signed in before.
+// The identity's stored email and verified flag are only overwritten with what the
+// provider itself reported, so an unreachable /userinfo never downgrades them.
+func (s *IdentityService) syncKnown(ctx context.Context, identity *models.UserIdentity, profile *ProviderProfile, fromProvider bool, req dto.SyncUserRequest) (*models.User, error) {
+	if fromProvider {
+		if err := s.store.TouchUserIdentity(ctx, identity.Subject, &profile.Email, profile.EmailVerified); err != nil {
+			return nil, err
+		}
+	}
+
+	username := req.Username
+	if ValidateUsername(username) != nil {
+		username = ""
+	}
+	user, err := s.store.SyncUserFromProvider(ctx, identity.UserID, profile.Email, username)
+	if errors.Is(err, apperr.ErrNotFound) {
+		// the identity belongs to an account that is waiting to be purged
+		return nil, apperr.ErrAccountDeleted
+	}
+	return user, err
+}
+
+// createUser signs up a new account for subject. The username the client sent is
+// cleaned up to satisfy the username rules and, if taken, given a numeric suffix.
+func (s *IdentityService) createUser(ctx context.Context, subject string, profile *ProviderProfile, req dto.SyncUserRequest) (*models.User, error) {
+	if _, err := s.store.GetUserByEmail(ctx, profile.Email); err == nil {
+		return nil, apperr.ErrEmailTaken
+	} else if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+
+	base := normalizeUsername(req.Username)
+	if base == "" {
+		base = normalizeUsername(profile.Email)
+	}
+	identity := &models.UserIdentity{Subject: subject, Email: &profile.Email, EmailVerified: profile.EmailVerified}
+
+	for range usernameAttempts {
+		username, err := availableUsername(ctx, s.store, base)
+		if err != nil {
+			return nil, err
+		}
+		displayName := req.DisplayName
+		if displayName == "" {
+			displayName = username
+		}
+
+		user, err := s.store.CreateUser(ctx, &models.User{
+			Email:       profile.Email,
+			Username:    username,
+			DisplayName: displayName,
+		}, identity)
+		if !errors.Is(err, apperr.ErrDuplicate) {
+			return user, err
+		}
+
+		// lost a race: either the email or the username was just taken
+		if _, err := s.store.GetUserByEmail(ctx, profile.Email); err == nil {
+			return nil, apperr.ErrEmailTaken
+		}
+		if _, err := s.store.GetUserIdentity(ctx, subject); err == nil {
+			return nil, apperr.ErrDuplicate
+		}
+	}
+	return nil, apperr.ErrDuplicate
+}
+
+// CreateLinkCode issues a one-time code that attaches whichever identity redeems it
+// to userID's account. Only a hash of the code is stored.
+func (s *IdentityService) CreateLinkCode(ctx context.Context, userID int) (*dto.LinkCodeResponse, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate link code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(b)
+	expiresAt := time.Now().Add(accountLinkCodeTTL)
+
+	if err := s.store.CreateAccountLinkCode(ctx, userID, hashLinkCode(code), expiresAt); err != nil {
+		return nil, err
+	}
+	return &dto.LinkCodeResponse{Code: code, ExpiresAt: expiresAt}, nil
+}
+
+// Link redeems a link code for subject. A subject with no account is attached to the
+// code's account; one that already has its own account gets that account merged in.
+// Holding a session for both identities is the proof of ownership, so no email check applies.
+func (s *IdentityService) Link(ctx context.Context, subject string, accessToken string, code string) (*models.User, error) {
+	targetID, err := s.store.ConsumeAccountLinkCode(ctx, hashLinkCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.store.GetUserIdentity(ctx, subject)
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		profile, _ := s.providerProfile(ctx, accessToken, "")
+		if err := s.addIdentity(ctx, subject, targetID, profile); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case identity.UserID != targetID:
+		if err := s.store.MergeUsers(ctx, identity.UserID, targetID); err != nil {
+			if errors.Is(err, apperr.ErrNotFound) {
+				return nil, apperr.ErrAccountDeleted
+			}
+			return nil, err
+		}
+	}
+	return s.store.GetUserByID(ctx, targetID)
+}
+
+// ListIdentities returns the identities userID can sign in with.
+func (s *IdentityService) ListIdentities(ctx context.Context, userID int) ([]models.UserIdentity, error) {
+	return s.store.ListUserIdentities(ctx, userID)
+}
+
+// Unlink detaches subject from userID's account. The identity the caller is signed
+// in with can't be unlinked, so an account always keeps one. An unlinked identity
+// with a verified email shared with the account joins it again on its next sign-in.
+func (s *IdentityService) Unlink(ctx context.Context, userID int, currentSubject string, subject string) error {
+	if subject == currentSubject {
+		return apperr.ErrUnlinkCurrentIdentity
+	}
+	return s.store.DeleteUserIdentity(ctx, userID, subject)
+}
+
+// addIdentity links subject to userID, recording what the provider reports about its email.
+func (s *IdentityService) addIdentity(ctx context.Context, subject string, userID int, profile *ProviderProfile) error {
+	identity := &models.UserIdentity{Subject: subject, UserID: userID, EmailVerified: profile.EmailVerified}
+	if profile.Email != "" {
+		identity.Email = &profile.Email
+	}
+	return s.store.AddUserIdentity(ctx, identity)
+}
+
+// providerProfile asks Auth0 about the caller. Without Auth0 (or if it can't be
+// reached) it falls back to the email the client sent, which is never treated as verified.
+// fromProvider is false when Auth0 is configured but didn't answer for this request;
+// the fallback then must not replace anything Auth0 reported earlier.
+func (s *IdentityService) providerProfile(ctx context.Context, accessToken string, fallbackEmail string) (profile *ProviderProfile, fromProvider bool) {
+	fallback := &ProviderProfile{Email: fallbackEmail}
+	if s.userInfo == nil {
+		return fallback, true
+	}
+	if accessToken == "" {
+		return fallback, false
+	}
+
+	profile, err := s.userInfo.Fetch(ctx, accessToken)
+	if err != nil {
+		log.Printf("[identity] %v", err)
+		return fallback, false
+	}
+	if profile.Email == "" {
+		profile.Email = fallbackEmail
+		profile.EmailVerified = false
+	}
+	return profile, true
+}
+
+// hashLinkCode is the stored form of a link code.
+func hashLinkCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
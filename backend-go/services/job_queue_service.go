@@ -12,43 +12,72 @@ import (
 
 // JobQueueService manages the thumbnail processing pipeline: claims queued videos and dispatches
 // them to a bounded worker pool via a periodic scheduler.
+// When location stripping is enabled, processed videos are then queued for the remux,
+// which runs on its own pool and scheduler the same way.
+// Personal data exports run on a third, single-worker pool: archives are built in memory.
 
 // Completely decoupled from the upload pipeline — claims videos with thumbnail_status = 'queued'
 type JobQueueService struct {
-	store          *database.Store
-	thumbnail      *ThumbnailService
-	pool           *workers.Pool
-	scheduler      *workers.Scheduler
-	stuckThreshold time.Duration
-	resetInterval  time.Duration
+	store           *database.Store
+	thumbnail       *ThumbnailService
+	locationStrip   *LocationStripService // nil when location stripping is disabled
+	dataExport      *DataExportService
+	notifications   *NotificationService
+	pool            *workers.Pool
+	scheduler       *workers.Scheduler
+	stripPool       *workers.Pool
+	stripScheduler  *workers.Scheduler
+	exportPool      *workers.Pool
+	exportScheduler *workers.Scheduler
+	stuckThreshold  time.Duration
+	resetInterval   time.Duration
 }
 
 func NewJobQueueService(
 	store *database.Store,
 	thumbnail *ThumbnailService,
+	locationStrip *LocationStripService,
+	dataExport *DataExportService,
+	notifications *NotificationService,
 	concurrency, queueSize int,
 	schedulerInterval, stuckThreshold, resetInterval time.Duration,
 ) *JobQueueService {
 	jqs := &JobQueueService{
 		store:          store,
 		thumbnail:      thumbnail,
+		locationStrip:  locationStrip,
+		dataExport:     dataExport,
+		notifications:  notifications,
 		pool:           workers.NewPool("thumbnail", concurrency, queueSize),
+		exportPool:     workers.NewPool("data-export", 1, queueSize),
 		stuckThreshold: stuckThreshold,
 		resetInterval:  resetInterval,
 	}
 	jqs.scheduler = workers.NewScheduler("thumbnail", jqs.pool, jqs.fetch, schedulerInterval)
+	jqs.exportScheduler = workers.NewScheduler("data-export", jqs.exportPool, jqs.fetchExports, schedulerInterval)
+	if locationStrip != nil {
+		jqs.stripPool = workers.NewPool("location-strip", concurrency, queueSize)
+		jqs.stripScheduler = workers.NewScheduler("location-strip", jqs.stripPool, jqs.fetchStrips, schedulerInterval)
+	}
 	return jqs
 }
 
-// Start launches the pool, scheduler, and reset loop in background goroutines.
+// Start launches the pools, schedulers, and reset loop in background goroutines.
 func (jqs *JobQueueService) Start(ctx context.Context) {
 	go jqs.pool.Run(ctx)
 	go jqs.scheduler.Run(ctx)
+	if jqs.locationStrip != nil {
+		go jqs.stripPool.Run(ctx)
+		go jqs.stripScheduler.Run(ctx)
+	}
+	go jqs.exportPool.Run(ctx)
+	go jqs.exportScheduler.Run(ctx)
 	go jqs.runResetLoop(ctx)
 	log.Println("[job-queue] started")
 }
 
-// runResetLoop periodically resets videos stuck in thumbnail_status = 'processing'.
+// runResetLoop periodically resets videos stuck in thumbnail_status = 'processing'
+// (and the other pipelines' stuck jobs), and deletes expired data export archives.
 // Decoupled from fetch so the reset cadence is independent of the scheduler poll interval.
 func (jqs *JobQueueService) runResetLoop(ctx context.Context) {
 	ticker := time.NewTicker(jqs.resetInterval)
@@ -61,6 +90,17 @@ func (jqs *JobQueueService) runResetLoop(ctx context.Context) {
 			if err := jqs.store.ResetStuckThumbnailVideos(ctx, jqs.stuckThreshold); err != nil {
 				log.Printf("[job-queue] failed to reset stuck thumbnail jobs: %v", err)
 			}
+			if jqs.locationStrip != nil {
+				if err := jqs.store.ResetStuckLocationStrips(ctx, jqs.stuckThreshold); err != nil {
+					log.Printf("[job-queue] failed to reset stuck location strip jobs: %v", err)
+				}
+			}
+			if err := jqs.store.ResetStuckDataExports(ctx, jqs.stuckThreshold); err != nil {
+				log.Printf("[job-queue] failed to reset stuck data export jobs: %v", err)
+			}
+			if err := jqs.dataExport.DeleteExpired(ctx); err != nil {
+				log.Printf("[job-queue] failed to delete expired data exports: %v", err)
+			}
 		}
 	}
 }
@@ -79,13 +119,82 @@ func (jqs *JobQueueService) fetch(ctx context.Context, limit int) ([]workers.Job
 	return jobs, nil
 }
 
-// processingJob returns a Job that runs the thumbnail pipeline for a single video.
+// processingJob returns a Job that runs the thumbnail pipeline for a single video
+// and tells the uploader how it went.
 func (jqs *JobQueueService) processingJob(v *models.Video) workers.Job {
 	return func(ctx context.Context) error {
 		if err := jqs.thumbnail.Extract(ctx, v); err != nil {
 			if ferr := jqs.store.SetThumbnailStatusFailed(ctx, v.ID); ferr != nil {
 				log.Printf("[job-queue] video %d: failed to mark thumbnail as failed: %v", v.ID, ferr)
 			}
+			jqs.notifications.Notify(ctx, models.Notification{
+				UserID:  v.UserID,
+				Type:    models.NotificationVideoProcessingFailed,
+				VideoID: &v.ID,
+			})
+			return err
+		}
+		if jqs.locationStrip != nil {
+			if err := jqs.store.QueueLocationStrip(ctx, v.ID); err != nil {
+				log.Printf("[job-queue] video %d: failed to queue location strip: %v", v.ID, err)
+			}
+		}
+		jqs.notifications.Notify(ctx, models.Notification{
+			UserID:  v.UserID,
+			Type:    models.NotificationVideoProcessed,
+			VideoID: &v.ID,
+		})
+		return nil
+	}
+}
+
+// fetchStrips bridges Postgres → worker jobs for the location strip remux.
+func (jqs *JobQueueService) fetchStrips(ctx context.Context, limit int) ([]workers.Job, error) {
+	videos, err := jqs.store.ClaimQueuedLocationStrips(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]workers.Job, len(videos))
+	for i, v := range videos {
+		jobs[i] = jqs.stripJob(v)
+	}
+	return jobs, nil
+}
+
+// stripJob returns a Job that writes the location-stripped copy of a single video.
+// Until it succeeds the video keeps serving its original upload.
+func (jqs *JobQueueService) stripJob(v *models.Video) workers.Job {
+	return func(ctx context.Context) error {
+		if err := jqs.locationStrip.Strip(ctx, v); err != nil {
+			if ferr := jqs.store.SetLocationStripFailed(ctx, v.ID); ferr != nil {
+				log.Printf("[job-queue] video %d: failed to mark location strip as failed: %v", v.ID, ferr)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// fetchExports bridges Postgres → worker jobs for personal data exports.
+func (jqs *JobQueueService) fetchExports(ctx context.Context, limit int) ([]workers.Job, error) {
+	exports, err := jqs.store.ClaimQueuedDataExports(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]workers.Job, len(exports))
+	for i, e := range exports {
+		jobs[i] = jqs.exportJob(e)
+	}
+	return jobs, nil
+}
+
+// exportJob returns a Job that builds and delivers a single data export.
+func (jqs *JobQueueService) exportJob(e *models.DataExport) workers.Job {
+	return func(ctx context.Context) error {
+		if err := jqs.dataExport.Build(ctx, e); err != nil {
+			jqs.dataExport.Fail(ctx, e)
 			return err
 		}
 		return nil
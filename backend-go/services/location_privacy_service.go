@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// approximateLocationScale rounds approximate coordinates to 2 decimal places (~1 km).
+const approximateLocationScale = 100
+
+// LocationPrivacyService applies uploaders' location_privacy settings to video responses.
+// It only touches what is sent to other viewers; stored coordinates stay precise for detection.
+type LocationPrivacyService struct {
+	store *database.Store
+}
+
+func NewLocationPrivacyService(store *database.Store) *LocationPrivacyService {
+	return &LocationPrivacyService{store: store}
+}
+
+// Apply rounds or removes the coordinates of videos viewerID (0 when anonymous) doesn't own,
+// following each uploader's setting. Owners always see their precise coordinates.
+func (s *LocationPrivacyService) Apply(ctx context.Context, viewerID int, videos []*models.Video) error {
+	ownerIDs := make([]int, 0)
+	seen := make(map[int]bool)
+	for _, v := range videos {
+		if v.UserID == viewerID || (v.Latitude == nil && v.Longitude == nil) || seen[v.UserID] {
+			continue
+		}
+		seen[v.UserID] = true
+		ownerIDs = append(ownerIDs, v.UserID)
+	}
+	if len(ownerIDs) == 0 {
+		return nil
+	}
+
+	settings, err := s.store.ListUserLocationPrivacy(ctx, ownerIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range videos {
+		if !seen[v.UserID] {
+			continue
+		}
+		switch settings[v.UserID] {
+		case models.LocationPrivacyPrecise:
+		case models.LocationPrivacyHidden:
+			v.Latitude, v.Longitude = nil, nil
+		default:
+			v.Latitude, v.Longitude = roundCoordinate(v.Latitude), roundCoordinate(v.Longitude)
+		}
+	}
+	return nil
+}
+
+func roundCoordinate(c *float64) *float64 {
+	if c == nil {
+		return nil
+	}
+	rounded := math.Round(*c*approximateLocationScale) / approximateLocationScale
+	return &rounded
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// LocationStripService writes a copy of a video without container metadata, so the file
+// viewers download carries no GPS location atoms. The original upload stays private and
+// keeps its metadata; video_url switches to the copy once it is in place.
+type LocationStripService struct {
+	store  *database.Store
+	media  *MediaService
+	upload *UploadService
+}
+
+func NewLocationStripService(store *database.Store, media *MediaService, upload *UploadService) *LocationStripService {
+	return &LocationStripService{store: store, media: media, upload: upload}
+}
+
+// Strip runs the remux for a single video and points the video at the stripped copy.
+func (ls *LocationStripService) Strip(ctx context.Context, video *models.Video) error {
+	presignedURL, err := ls.upload.PresignGet(ctx, video.S3Key, presignGetTTL)
+	if err != nil {
+		return fmt.Errorf("presign GET: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", fmt.Sprintf("relive-strip-%d-*.mp4", video.ID))
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := ls.media.RemuxWithoutMetadata(ctx, presignedURL, tmp.Name()); err != nil {
+		return err
+	}
+
+	// the copy shares its video's visibility; re-read it in case the owner changed it mid-remux.
+	public := video.Visibility == models.VideoVisibilityPublic
+	if current, err := ls.store.GetVideoByID(ctx, video.ID); err == nil {
+		public = current.Visibility == models.VideoVisibilityPublic
+	}
+
+	key := videoPlaybackKey(video.ID)
+	videoURL, err := ls.upload.PutFile(ctx, key, tmp.Name(), "video/mp4", public)
+	if err != nil {
+		return err
+	}
+	if err := ls.store.SetLocationStripCompleted(ctx, video.ID, key, videoURL); err != nil {
+		return err
+	}
+
+	// the original is no longer served; keep it private from here on.
+	if public {
+		if err := ls.upload.SetObjectPublic(ctx, video.S3Key, false); err != nil {
+			log.Printf("[location-strip] video %d: failed to make original private: %v", video.ID, err)
+		}
+	}
+	return nil
+}
+
+// videoPlaybackKey returns the S3 key of a video's location-stripped copy.
+func videoPlaybackKey(videoID int) string {
+	return fmt.Sprintf("playback/%d.mp4", videoID)
+}
@@ -191,6 +191,28 @@ func (m *MediaService) ExtractFrame(ctx context.Context, filePath string, offset
 	return output, nil
 }
 
+// RemuxWithoutMetadata copies the video and audio streams of the given URL or file path into
+// an MP4 at outputPath without re-encoding, dropping all container and stream metadata
+// (GPS location atoms included) and any data tracks.
+func (m *MediaService) RemuxWithoutMetadata(ctx context.Context, filePath string, outputPath string) error {
+	cmd := exec.CommandContext(ctx, "ffmpeg",
+		"-y",
+		"-i", filePath,
+		"-map", "0:v", // video and audio only — data tracks can carry location too
+		"-map", "0:a?",
+		"-map_metadata", "-1", // drop global and per-stream metadata
+		"-c", "copy", // stream copy, no re-encode
+		"-movflags", "+faststart",
+		"-f", "mp4",
+		outputPath,
+	)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ffmpeg remux failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // ==============================
 // ACRCloud SDK
 // ==============================
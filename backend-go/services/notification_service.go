@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// NotificationService is the in-app inbox. Other services publish into it with
+// Notify; users read and clear it through the /notifications endpoints.
+type NotificationService struct {
+	store         *database.Store
+	searchService *SearchService
+}
+
+func NewNotificationService(store *database.Store, searchService *SearchService) *NotificationService {
+	return &NotificationService{store: store, searchService: searchService}
+}
+
+// notificationCursor is the keyset position of the last notification on a page.
+type notificationCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+// Notify publishes n to its recipient's inbox unless they turned the type off.
+// Notifications never fail the action that caused them, so errors are only logged.
+// Self-notifications (actor == recipient) are dropped.
+func (s *NotificationService) Notify(ctx context.Context, n models.Notification) {
+	if n.ActorID != nil && *n.ActorID == n.UserID {
+		return
+	}
+	if _, err := s.store.CreateNotification(ctx, &n); err != nil {
+		log.Printf("[notifications] failed to notify user %d (%s): %v", n.UserID, n.Type, err)
+	}
+}
+
+// List pages through userID's notifications, newest first, with their unread count.
+func (s *NotificationService) List(ctx context.Context, userID int, req dto.NotificationsRequest) (*dto.NotificationsResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeNotifications, EntityID: userID, Query: strconv.FormatBool(req.Unread)}
+	var afterCreatedAt *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after notificationCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
+
+	notifications, err := s.store.ListNotifications(ctx, userID, req.Unread, afterCreatedAt, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	notifications, hasMore := trimPage(notifications, req.Limit)
+
+	unread, err := s.store.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.buildItems(ctx, notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *notificationCursor
+	if len(notifications) > 0 {
+		n := notifications[len(notifications)-1]
+		last = &notificationCursor{CreatedAt: n.CreatedAt, ID: n.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.NotificationsResponse{Results: results, UnreadCount: unread, Meta: meta}, nil
+}
+
+// UnreadCount returns how many of userID's notifications are unread.
+func (s *NotificationService) UnreadCount(ctx context.Context, userID int) (int, error) {
+	return s.store.CountUnreadNotifications(ctx, userID)
+}
+
+// MarkRead marks one of userID's notifications read.
+func (s *NotificationService) MarkRead(ctx context.Context, userID int, notificationID int) error {
+	found, err := s.store.MarkNotificationRead(ctx, userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperr.ErrNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks every notification userID has so far as read.
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int) error {
+	return s.store.MarkAllNotificationsRead(ctx, userID, time.Now())
+}
+
+// Preferences returns whether each notification type is enabled for userID.
+func (s *NotificationService) Preferences(ctx context.Context, userID int) (dto.NotificationPreferences, error) {
+	stored, err := s.store.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return expandNotificationPreferences(stored), nil
+}
+
+// UpdatePreferences turns the given notification types on or off, leaving the rest as they were.
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID int, changes dto.NotificationPreferences) (dto.NotificationPreferences, error) {
+	for t := range changes {
+		if !slices.Contains(models.NotificationTypes, t) {
+			return nil, fmt.Errorf("%w: %q", apperr.ErrInvalidNotificationType, t)
+		}
+	}
+
+	stored, err := s.store.UpdateNotificationPreferences(ctx, userID, changes)
+	if err != nil {
+		return nil, err
+	}
+	return expandNotificationPreferences(stored), nil
+}
+
+// expandNotificationPreferences fills in every known type; types missing from stored are enabled.
+func expandNotificationPreferences(stored map[string]bool) dto.NotificationPreferences {
+	prefs := make(dto.NotificationPreferences, len(models.NotificationTypes))
+	for _, t := range models.NotificationTypes {
+		enabled, ok := stored[t]
+		prefs[t] = !ok || enabled
+	}
+	return prefs
+}
+
+// buildItems expands each notification's actor.
+func (s *NotificationService) buildItems(ctx context.Context, notifications []models.Notification) ([]dto.NotificationItem, error) {
+	actorIDs := make([]int, 0, len(notifications))
+	for _, n := range notifications {
+		if n.ActorID != nil {
+			actorIDs = append(actorIDs, *n.ActorID)
+		}
+	}
+	actors, err := s.store.ListUsersByIDs(ctx, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+	actorsByID := indexBy(actors, func(u models.User) int { return u.ID })
+
+	items := make([]dto.NotificationItem, 0, len(notifications))
+	for _, n := range notifications {
+		item := dto.NotificationItem{
+			ID:        n.ID,
+			Type:      n.Type,
+			VideoID:   n.VideoID,
+			ConcertID: n.ConcertID,
+			CommentID: n.CommentID,
+			Data:      n.Data,
+			Read:      n.ReadAt != nil,
+			CreatedAt: n.CreatedAt,
+		}
+		if n.ActorID != nil {
+			if actor, ok := actorsByID[*n.ActorID]; ok {
+				item.Actor = userCompact(actor)
+			}
+		}
+		if len(item.Data) == 0 {
+			item.Data = nil
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+)
+
+// videoCursor is the keyset position of video lists ordered newest first (created_at DESC, id DESC).
+type videoCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int       `json:"id"`
+}
+
+// validatePageLimit checks that limit is within allowed bounds.
+func validatePageLimit(limit int) error {
+	if limit <= 0 || limit > dto.PageLimitMax {
+		return fmt.Errorf("invalid limit: %d (must be 1-%d)", limit, dto.PageLimitMax)
+	}
+	return nil
+}
+
+// trimPage cuts a limit+1 result set down to limit and reports whether more rows exist.
+func trimPage[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
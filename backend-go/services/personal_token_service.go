@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// personalTokenPrefixLength is how much of a token is kept in the clear to tell tokens apart.
+const personalTokenPrefixLength = len(models.PersonalAccessTokenPrefix) + 4
+
+// PersonalTokenService manages the scoped personal access tokens users create for
+// scripts. Authenticating with them is middleware.NewPersonalTokenAuthenticator's job.
+type PersonalTokenService struct {
+	store *database.Store
+}
+
+func NewPersonalTokenService(store *database.Store) *PersonalTokenService {
+	return &PersonalTokenService{store: store}
+}
+
+// Create issues a new token for userID. The response holds the only copy of the token.
+func (s *PersonalTokenService) Create(ctx context.Context, userID int, req dto.CreatePersonalTokenRequest) (*dto.CreatePersonalTokenResponse, error) {
+	secret, err := newPersonalToken()
+	if err != nil {
+		return nil, err
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = dto.PersonalTokenExpiryDefault
+	}
+	scopes := slices.Clone(req.Scopes)
+	slices.Sort(scopes)
+	scopes = slices.Compact(scopes)
+
+	token, err := s.store.CreatePersonalAccessToken(ctx, &models.PersonalAccessToken{
+		UserID:      userID,
+		Name:        req.Name,
+		TokenHash:   models.HashPersonalAccessToken(secret),
+		TokenPrefix: secret[:personalTokenPrefixLength],
+		Scopes:      scopes,
+		ExpiresAt:   time.Now().AddDate(0, 0, days),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.CreatePersonalTokenResponse{Token: token, Secret: secret}, nil
+}
+
+// List returns userID's tokens that haven't been revoked, newest first.
+func (s *PersonalTokenService) List(ctx context.Context, userID int) (*dto.PersonalTokensResponse, error) {
+	tokens, err := s.store.ListPersonalAccessTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PersonalTokensResponse{Results: tokens}, nil
+}
+
+// Revoke stops one of userID's tokens from working.
+func (s *PersonalTokenService) Revoke(ctx context.Context, userID int, tokenID int) error {
+	return s.store.RevokePersonalAccessToken(ctx, userID, tokenID)
+}
+
+// newPersonalToken returns a random token carrying models.PersonalAccessTokenPrefix.
+func newPersonalToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	return models.PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// PlaybackService decides how video and thumbnail objects are reached.
+// Public videos are public-read objects served straight from the CDN; every other
+// video is a private object, handed out only as short-lived presigned URLs to
+// viewers already allowed to see it. The bucket itself must not grant anonymous reads.
+type PlaybackService struct {
+	upload *UploadService
+	ttl    time.Duration
+}
+
+func NewPlaybackService(upload *UploadService, ttl time.Duration) *PlaybackService {
+	return &PlaybackService{upload: upload, ttl: ttl}
+}
+
+// Sign swaps the CDN URLs of non-public videos for presigned ones that expire at
+// URLsExpireAt. Only call it with videos the viewer may see.
+func (s *PlaybackService) Sign(ctx context.Context, videos []*models.Video) error {
+	for _, v := range videos {
+		if v.Visibility == models.VideoVisibilityPublic {
+			continue
+		}
+		expiresAt := time.Now().Add(s.ttl)
+
+		videoURL, err := s.upload.PresignGet(ctx, playbackKey(v), s.ttl)
+		if err != nil {
+			return err
+		}
+		v.VideoURL = videoURL
+
+		if v.ThumbnailURL != nil {
+			thumbnailURL, err := s.upload.PresignGet(ctx, videoThumbnailKey(v.ID), s.ttl)
+			if err != nil {
+				return err
+			}
+			v.ThumbnailURL = &thumbnailURL
+		}
+		v.URLsExpireAt = &expiresAt
+	}
+	return nil
+}
+
+// SyncAccess makes a video's served objects CDN-readable when it is public and private otherwise.
+// The CDN may keep serving a cached copy of a newly private object until its cache expires.
+func (s *PlaybackService) SyncAccess(ctx context.Context, video *models.Video) error {
+	public := video.Visibility == models.VideoVisibilityPublic
+	if err := s.upload.SetObjectPublic(ctx, playbackKey(video), public); err != nil {
+		return err
+	}
+	if video.ThumbnailURL != nil {
+		return s.upload.SetObjectPublic(ctx, videoThumbnailKey(video.ID), public)
+	}
+	return nil
+}
+
+// playbackKey returns the S3 key video_url serves: the location-stripped copy once there is one.
+// The original upload stays private after that.
+func playbackKey(video *models.Video) string {
+	if video.PlaybackS3Key != nil {
+		return *video.PlaybackS3Key
+	}
+	return video.S3Key
+}
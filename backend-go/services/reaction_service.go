@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+// ReactionService owns video reactions and decorates video responses with engagement.
+type ReactionService struct {
+	store *database.Store
+}
+
+func NewReactionService(store *database.Store) *ReactionService {
+	return &ReactionService{store: store}
+}
+
+// React sets userID's reaction to a video they can see, replacing any earlier reaction.
+// shareToken opens an unlisted video ("" when the viewer has none).
+func (s *ReactionService) React(ctx context.Context, userID int, videoID int, shareToken string, reaction string) (*models.Reaction, error) {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if !videoVisibleTo(video, userID, shareToken) {
+		return nil, apperr.ErrNotFound
+	}
+	return s.store.UpsertReaction(ctx, videoID, userID, reaction)
+}
+
+// Unreact removes userID's reaction to a video, if any.
+func (s *ReactionService) Unreact(ctx context.Context, userID int, videoID int) error {
+	return s.store.DeleteReaction(ctx, videoID, userID)
+}
+
+// Attach fills in reaction counts on videos, and the viewer's own reaction when
+// viewerID is authenticated (!= 0). View counts come with the video row itself.
+func (s *ReactionService) Attach(ctx context.Context, viewerID int, videos []*models.Video) error {
+	if len(videos) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(videos))
+	for _, v := range videos {
+		ids = append(ids, v.ID)
+	}
+
+	counts, err := s.store.ListReactionCounts(ctx, ids)
+	if err != nil {
+		return err
+	}
+	var mine map[int]string
+	if viewerID != 0 {
+		mine, err = s.store.ListUserReactions(ctx, viewerID, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, v := range videos {
+		v.ReactionCounts = counts[v.ID]
+		if v.ReactionCounts == nil {
+			v.ReactionCounts = map[string]int{}
+		}
+		if reaction, ok := mine[v.ID]; ok {
+			v.MyReaction = &reaction
+		}
+	}
+	return nil
+}
@@ -1,17 +1,48 @@
 package services
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
 )
 
 // SearchService is a shared toolkit for local search operations.
 // Domain services (ArtistService, SongService) own their own search orchestration.
-type SearchService struct{}
+//
+// It also owns cursor signing, so every paginated endpoint issues cursors the
+// same way: base64url(JSON position) + "." + base64url(HMAC-SHA256). Clients
+// can't read or forge positions, and a cursor minted by one replica verifies on any other.
+type SearchService struct {
+	cursorKey []byte
+}
+
+func NewSearchService(cursorSigningKey []byte) *SearchService {
+	return &SearchService{cursorKey: cursorSigningKey}
+}
 
-func NewSearchService() *SearchService {
-	return &SearchService{}
+// search scopes, embedded in search cursors
+const (
+	searchScopeArtists  = "artists"
+	searchScopeConcerts = "concerts"
+	searchScopeSongs    = "songs"
+	searchScopeUsers    = "users"
+	searchScopeVenues   = "venues"
+)
+
+// searchCursor binds a search keyset to the scope, query and filters it was issued for,
+// so a cursor can't be replayed against a different endpoint, query string or filter set.
+type searchCursor struct {
+	Scope   string                `json:"sc"`
+	Query   string                `json:"q"`
+	Filters string                `json:"f,omitempty"`
+	Keyset  database.SearchKeyset `json:"k"`
 }
 
 // ValidateMaxResults checks that maxResults is within allowed bounds.
@@ -22,8 +53,29 @@ func (s *SearchService) ValidateMaxResults(maxResults int) error {
 	return nil
 }
 
-// BuildSearchMeta returns shared response metadata defaults for search responses.
-func (s *SearchService) BuildSearchMeta(req dto.SearchRequest, resultsReturned int) dto.SearchResponseMeta {
+// DecodeSearchCursor verifies req.Cursor and returns the keyset to resume after.
+// Returns nil for the first page (no cursor).
+func (s *SearchService) DecodeSearchCursor(scope string, req dto.SearchRequest) (*database.SearchKeyset, error) {
+	if req.Cursor == "" {
+		return nil, nil
+	}
+	var cursor searchCursor
+	if err := s.VerifyCursor(req.Cursor, &cursor); err != nil {
+		return nil, err
+	}
+	filters, err := searchFilterKey(req)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Scope != scope || cursor.Query != strings.TrimSpace(req.Q) || cursor.Filters != filters {
+		return nil, apperr.ErrInvalidCursor
+	}
+	return &cursor.Keyset, nil
+}
+
+// BuildSearchMeta returns shared response metadata for search responses.
+// next is the keyset of the last returned row when another page exists, nil otherwise.
+func (s *SearchService) BuildSearchMeta(scope string, req dto.SearchRequest, resultsReturned int, next *database.SearchKeyset) (dto.SearchResponseMeta, error) {
 	requestedMaxResults := req.MaxResults
 	if requestedMaxResults <= 0 {
 		requestedMaxResults = dto.SearchMaxResultsDefault
@@ -32,10 +84,151 @@ func (s *SearchService) BuildSearchMeta(req dto.SearchRequest, resultsReturned i
 		requestedMaxResults = dto.SearchMaxResultsMax
 	}
 
-	return dto.SearchResponseMeta{
+	meta := dto.SearchResponseMeta{
 		Query:               req.Q,
 		RequestedMaxResults: requestedMaxResults,
 		ResultsReturned:     resultsReturned,
-		HasMore:             false,
+		HasMore:             next != nil,
+	}
+	if next != nil {
+		filters, err := searchFilterKey(req)
+		if err != nil {
+			return dto.SearchResponseMeta{}, err
+		}
+		token, err := s.SignCursor(searchCursor{
+			Scope:   scope,
+			Query:   strings.TrimSpace(req.Q),
+			Filters: filters,
+			Keyset:  *next,
+		})
+		if err != nil {
+			return dto.SearchResponseMeta{}, err
+		}
+		meta.NextCursor = &token
+	}
+	return meta, nil
+}
+
+// PageScope identifies the list a page cursor was issued for: the endpoint, the entity
+// it lists under (artist, user, concert, ...) and its normalized query parameters.
+type PageScope struct {
+	Name     string
+	EntityID int
+	Query    string
+}
+
+// list scopes, embedded in page cursors
+const (
+	pageScopeArtistConcerts   = "artist_concerts"
+	pageScopeArtistSongs      = "artist_songs"
+	pageScopeArtistVideos     = "artist_videos"
+	pageScopeArtistFollowers  = "artist_followers"
+	pageScopeSongPerformances = "song_performances"
+	pageScopeVenueConcerts    = "venue_concerts"
+	pageScopeUserVideos       = "user_videos"
+	pageScopeUserConcerts     = "user_concerts"
+	pageScopeUserFollowers    = "user_followers"
+	pageScopeUserFollowing    = "user_following"
+	pageScopeFollowedArtists  = "followed_artists"
+	pageScopeConcertAttendees = "concert_attendees"
+	pageScopeComments         = "comments"
+	pageScopeCommentReplies   = "comment_replies"
+	pageScopeNotifications    = "notifications"
+	pageScopeHomeFeed         = "home_feed"
+	pageScopeRoleChanges      = "role_changes"
+	pageScopeCommentReports   = "comment_reports"
+)
+
+// pageCursor binds a list keyset position to its PageScope, like searchCursor does for search.
+type pageCursor struct {
+	Scope    string          `json:"sc"`
+	EntityID int             `json:"e,omitempty"`
+	Query    string          `json:"q,omitempty"`
+	Position json.RawMessage `json:"p"`
+}
+
+// BuildPageMeta signs the keyset position of the last returned row, bound to scope, as the next cursor.
+func (s *SearchService) BuildPageMeta(scope PageScope, hasMore bool, last any) (dto.PageMeta, error) {
+	if !hasMore {
+		return dto.PageMeta{HasMore: false}, nil
+	}
+	position, err := json.Marshal(last)
+	if err != nil {
+		return dto.PageMeta{}, fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	next, err := s.SignCursor(pageCursor{
+		Scope:    scope.Name,
+		EntityID: scope.EntityID,
+		Query:    scope.Query,
+		Position: position,
+	})
+	if err != nil {
+		return dto.PageMeta{}, err
 	}
+	return dto.PageMeta{NextCursor: &next, HasMore: true}, nil
+}
+
+// DecodePageCursor verifies a cursor from BuildPageMeta and parses its keyset into position.
+// A cursor issued for a different scope, entity or query is rejected with apperr.ErrInvalidCursor.
+func (s *SearchService) DecodePageCursor(scope PageScope, token string, position any) error {
+	var cursor pageCursor
+	if err := s.VerifyCursor(token, &cursor); err != nil {
+		return err
+	}
+	if cursor.Scope != scope.Name || cursor.EntityID != scope.EntityID || cursor.Query != scope.Query {
+		return apperr.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(cursor.Position, position); err != nil {
+		return apperr.ErrInvalidCursor
+	}
+	return nil
+}
+
+// SignCursor serializes a keyset position into an opaque, signed, URL-safe token.
+func (s *SearchService) SignCursor(position any) (string, error) {
+	raw, err := json.Marshal(position)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.sign(payload)), nil
+}
+
+// VerifyCursor checks a token produced by SignCursor and parses it into position.
+// Any malformed or tampered token is reported as apperr.ErrInvalidCursor.
+func (s *SearchService) VerifyCursor(token string, position any) error {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return apperr.ErrInvalidCursor
+	}
+	gotMAC, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotMAC, s.sign(payload)) {
+		return apperr.ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return apperr.ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, position); err != nil {
+		return apperr.ErrInvalidCursor
+	}
+	return nil
+}
+
+// searchFilterKey returns a canonical encoding of req's filters for cursor binding ("" when unfiltered).
+func searchFilterKey(req dto.SearchRequest) (string, error) {
+	if req.ConcertFilters == nil || req.ConcertFilters.IsEmpty() {
+		return "", nil
+	}
+	raw, err := json.Marshal(req.ConcertFilters)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode search filters: %w", err)
+	}
+	return string(raw), nil
+}
+
+func (s *SearchService) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, s.cursorKey)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
 }
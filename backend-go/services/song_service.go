@@ -2,19 +2,29 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
 	"github.com/areeeeeeeb/reLive/backend-go/models"
 )
 
 type SongService struct {
-	store         *database.Store
-	searchService *SearchService
+	store          *database.Store
+	searchService  *SearchService
+	concertService *ConcertService
 }
 
-func NewSongService(store *database.Store, searchService *SearchService) *SongService {
-	return &SongService{store: store, searchService: searchService}
+func NewSongService(store *database.Store, searchService *SearchService, concertService *ConcertService) *SongService {
+	return &SongService{store: store, searchService: searchService, concertService: concertService}
+}
+
+// songPlayCursor is the keyset position encoded into /songs/:id/performances cursors.
+type songPlayCursor struct {
+	Date time.Time `json:"d"`
+	ID   int       `json:"id"`
 }
 
 func (s *SongService) Get(ctx context.Context, id int) (*models.Song, error) {
@@ -22,28 +32,42 @@ func (s *SongService) Get(ctx context.Context, id int) (*models.Song, error) {
 }
 
 func (s *SongService) Search(ctx context.Context, req dto.SearchRequest) (*dto.SongSearchResponse, error) {
+	resp, _, err := s.searchRanked(ctx, req)
+	return resp, err
+}
+
+// searchRanked is Search, also returning each result's ranking position.
+func (s *SongService) searchRanked(ctx context.Context, req dto.SearchRequest) (*dto.SongSearchResponse, []database.SearchKeyset, error) {
 	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	songs, err := s.store.SearchSongs(ctx, req.Q, req.MaxResults)
+	after, err := s.searchService.DecodeSearchCursor(searchScopeSongs, req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	page, err := s.store.SearchSongs(ctx, req.Q, after, req.MaxResults)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	artistIDs := collectSongArtistIDs(songs)
+	artistIDs := collectSongArtistIDs(page.Items)
 	artists, err := s.store.ListArtistsByIDs(ctx, artistIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	artistsByID := indexBy(artists, func(a models.Artist) int { return a.ID })
 
-	results := s.BuildSearchResults(songs, artistsByID)
-	meta := s.searchService.BuildSearchMeta(req, len(results))
+	results := s.BuildSearchResults(page.Items, artistsByID)
+	meta, err := s.searchService.BuildSearchMeta(searchScopeSongs, req, len(results), page.Next)
+	if err != nil {
+		return nil, nil, err
+	}
 	return &dto.SongSearchResponse{
 		Results: results,
 		Meta:    meta,
-	}, nil
+	}, page.Keys, nil
 }
 
 func (s *SongService) BuildSearchResults(
@@ -93,3 +117,99 @@ func collectSongArtistIDs(songs []models.Song) []int {
 
 	return artistIDs
 }
+
+// ListPerformances returns every concert the song was played at, most recent first.
+func (s *SongService) ListPerformances(ctx context.Context, songID int, req dto.PageRequest) (*dto.SongPerformancesResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	song, err := s.store.GetSongByID(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeSongPerformances, EntityID: songID}
+	var afterDate *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after songPlayCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterDate, afterID = &after.Date, after.ID
+	}
+
+	plays, err := s.store.ListPlaysBySong(ctx, songID, afterDate, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	plays, hasMore := trimPage(plays, req.Limit)
+
+	concerts := make([]models.Concert, 0, len(plays))
+	performerIDs := make([]int, 0, len(plays))
+	for _, p := range plays {
+		concerts = append(concerts, p.Concert)
+		performerIDs = append(performerIDs, p.PerformerArtistID)
+	}
+	cards, err := s.concertService.BuildCards(ctx, concerts)
+	if err != nil {
+		return nil, err
+	}
+	performers, err := s.store.ListArtistsByIDs(ctx, performerIDs)
+	if err != nil {
+		return nil, err
+	}
+	performersByID := indexBy(performers, func(a models.Artist) int { return a.ID })
+
+	results := make([]dto.SongPerformanceItem, 0, len(plays))
+	for i, p := range plays {
+		var performer *dto.ArtistCompact
+		if artist, ok := performersByID[p.PerformerArtistID]; ok {
+			performer = &dto.ArtistCompact{
+				ID:       artist.ID,
+				Name:     artist.Name,
+				ImageURL: artist.ImageURL,
+			}
+		}
+		results = append(results, dto.SongPerformanceItem{
+			ID:        p.ID,
+			Position:  p.Position,
+			IsCover:   song.ArtistID != nil && *song.ArtistID != p.PerformerArtistID,
+			Performer: performer,
+			Concert:   cards[i],
+		})
+	}
+
+	var last *songPlayCursor
+	if len(plays) > 0 {
+		p := plays[len(plays)-1]
+		last = &songPlayCursor{Date: p.Concert.Date, ID: p.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SongPerformancesResponse{Results: results, Meta: meta}, nil
+}
+
+// Stats returns the song's live statistics from the song_performance_stats view.
+// A song that exists but has never been played (or was added since the last refresh) gets zeroed stats.
+func (s *SongService) Stats(ctx context.Context, songID int) (*models.SongPerformanceStats, error) {
+	stats, err := s.store.GetSongPerformanceStats(ctx, songID)
+	if err == nil {
+		return stats, nil
+	}
+	if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
+	}
+
+	exists, err := s.store.SongExists(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.ErrNotFound
+	}
+	return &models.SongPerformanceStats{SongID: songID}, nil
+}
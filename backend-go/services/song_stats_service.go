@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+)
+
+// SongStatsService keeps the song_performance_stats materialized view fresh.
+// Stats are read-heavy and tolerate staleness, so we refresh on a timer instead of on every setlist write.
+type SongStatsService struct {
+	store           *database.Store
+	refreshInterval time.Duration
+}
+
+func NewSongStatsService(store *database.Store, refreshInterval time.Duration) *SongStatsService {
+	return &SongStatsService{store: store, refreshInterval: refreshInterval}
+}
+
+// Start launches the refresh loop in a background goroutine.
+func (s *SongStatsService) Start(ctx context.Context) {
+	go s.runRefreshLoop(ctx)
+	log.Println("[song-stats] started")
+}
+
+// runRefreshLoop refreshes once on start, then every refreshInterval until ctx is done.
+func (s *SongStatsService) runRefreshLoop(ctx context.Context) {
+	s.refresh(ctx)
+
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.refresh(ctx)
+		}
+	}
+}
+
+func (s *SongStatsService) refresh(ctx context.Context) {
+	start := time.Now()
+	if err := s.store.RefreshSongPerformanceStats(ctx); err != nil {
+		log.Printf("[song-stats] failed to refresh song_performance_stats: %v", err)
+		return
+	}
+	log.Printf("[song-stats] refreshed song_performance_stats in %s", time.Since(start))
+}
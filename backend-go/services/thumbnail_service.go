@@ -63,9 +63,13 @@ func (ts *ThumbnailService) Extract(ctx context.Context, video *models.Video) er
 	if err != nil {
 		log.Printf("[thumbnail] video %d: ExtractFrame failed: %v", video.ID, err)
 	} else {
-		// step 6: upload thumbnail and persist URL
-		thumbnailKey := fmt.Sprintf("thumbnails/%d.jpg", video.ID)
-		thumbnailURL, err := ts.upload.PutObject(ctx, thumbnailKey, frame, "image/jpeg")
+		// step 6: upload thumbnail and persist URL. thumbnails share their video's
+		// visibility; re-read it in case the owner changed it mid-extraction.
+		public := video.Visibility == models.VideoVisibilityPublic
+		if current, err := ts.store.GetVideoByID(ctx, video.ID); err == nil {
+			public = current.Visibility == models.VideoVisibilityPublic
+		}
+		thumbnailURL, err := ts.upload.PutObject(ctx, videoThumbnailKey(video.ID), frame, "image/jpeg", public)
 		if err != nil {
 			// thumbnail failure is soft — video is still watchable, thumbnail_url stays nil.
 			log.Printf("[thumbnail] video %d: thumbnail upload failed: %v", video.ID, err)
@@ -77,3 +81,8 @@ func (ts *ThumbnailService) Extract(ctx context.Context, video *models.Video) er
 	// step 7: mark thumbnail extraction complete regardless of outcome.
 	return ts.store.SetThumbnailStatusCompleted(ctx, video.ID)
 }
+
+// videoThumbnailKey returns the S3 key of a video's thumbnail.
+func videoThumbnailKey(videoID int) string {
+	return fmt.Sprintf("thumbnails/%d.jpg", videoID)
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+)
+
+// unifiedSearchSections is the order sections run in and the tiebreak order for equal top-hit scores.
+var unifiedSearchSections = []string{
+	dto.SearchTypeArtists,
+	dto.SearchTypeSongs,
+	dto.SearchTypeConcerts,
+	dto.SearchTypeUsers,
+}
+
+// UnifiedSearchService backs the omnibox: it runs the per-entity searches
+// concurrently and merges their best rows into one top-hits list.
+//
+// All /search requests share one DB budget (queries) so a burst of keystrokes
+// can't take the whole connection pool, and every section of a request shares
+// one deadline so a slow section can't hold the response back.
+type UnifiedSearchService struct {
+	artistService  *ArtistService
+	songService    *SongService
+	concertService *ConcertService
+	userService    *UserService
+
+	queries chan struct{} // one slot per in-flight section
+	timeout time.Duration
+}
+
+func NewUnifiedSearchService(
+	artistService *ArtistService,
+	songService *SongService,
+	concertService *ConcertService,
+	userService *UserService,
+	maxConcurrentQueries int,
+	timeout time.Duration,
+) *UnifiedSearchService {
+	return &UnifiedSearchService{
+		artistService:  artistService,
+		songService:    songService,
+		concertService: concertService,
+		userService:    userService,
+		queries:        make(chan struct{}, max(maxConcurrentQueries, 1)),
+		timeout:        timeout,
+	}
+}
+
+// Search runs every requested section and returns whatever finished in time.
+// It fails only when no section succeeded.
+func (s *UnifiedSearchService) Search(ctx context.Context, req dto.UnifiedSearchRequest) (*dto.UnifiedSearchResponse, error) {
+	sections, err := parseSearchTypes(req.Types)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	sectionReq := dto.SearchRequest{Q: req.Q, MaxResults: req.Limit, ViewerID: req.ViewerID}
+	resp := &dto.UnifiedSearchResponse{Query: req.Q}
+
+	hits := make([][]dto.TopHit, len(unifiedSearchSections))
+	errs := make([]error, len(unifiedSearchSections))
+	var wg sync.WaitGroup
+	for i, section := range unifiedSearchSections {
+		if !sections[section] {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.withQuerySlot(ctx, func() error {
+				var err error
+				hits[i], err = s.searchSection(ctx, section, sectionReq, resp)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+
+	var merged []dto.TopHit
+	var firstErr error
+	for i, section := range unifiedSearchSections {
+		if !sections[section] {
+			continue
+		}
+		if errs[i] != nil {
+			log.Printf("unified search: %s section failed for %q: %v", section, req.Q, errs[i])
+			resp.FailedSections = append(resp.FailedSections, section)
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		merged = append(merged, hits[i]...)
+	}
+	if len(resp.FailedSections) == len(sections) {
+		return nil, firstErr
+	}
+
+	// stable: equal scores keep section order, then each section's own ranking
+	sort.SliceStable(merged, func(a, b int) bool { return merged[a].Score > merged[b].Score })
+	if len(merged) > dto.UnifiedSearchTopHitsMax {
+		merged = merged[:dto.UnifiedSearchTopHitsMax]
+	}
+	resp.TopHits = merged
+	return resp, nil
+}
+
+// searchSection runs one section through its domain service, stores the section
+// on resp, and returns the section's rows as scored top-hit candidates. Domain
+// services expose searchRanked, which returns each row's ranking position alongside
+// the section, so rows can be merged into top hits.
+// Each section writes a different field of resp, so sections can run concurrently.
+func (s *UnifiedSearchService) searchSection(
+	ctx context.Context,
+	section string,
+	req dto.SearchRequest,
+	resp *dto.UnifiedSearchResponse,
+) ([]dto.TopHit, error) {
+	switch section {
+	case dto.SearchTypeArtists:
+		result, keys, err := s.artistService.searchRanked(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Artists = result
+		return buildTopHits(result.Results, keys, func(hit *dto.TopHit, item *dto.ArtistSearchItem) {
+			hit.Type, hit.Artist = section, item
+		}), nil
+	case dto.SearchTypeSongs:
+		result, keys, err := s.songService.searchRanked(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Songs = result
+		return buildTopHits(result.Results, keys, func(hit *dto.TopHit, item *dto.SongSearchItem) {
+			hit.Type, hit.Song = section, item
+		}), nil
+	case dto.SearchTypeConcerts:
+		result, keys, err := s.concertService.searchRanked(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Concerts = result
+		return buildTopHits(result.Results, keys, func(hit *dto.TopHit, item *dto.ConcertSearchItem) {
+			hit.Type, hit.Concert = section, item
+		}), nil
+	case dto.SearchTypeUsers:
+		result, keys, err := s.userService.searchRanked(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Users = result
+		return buildTopHits(result.Results, keys, func(hit *dto.TopHit, item *dto.UserSearchItem) {
+			hit.Type, hit.User = section, item
+		}), nil
+	}
+	return nil, fmt.Errorf("%w: %s", apperr.ErrInvalidSearchType, section)
+}
+
+// withQuerySlot runs fn once a slot in the shared DB budget is free,
+// giving up if ctx expires while waiting.
+func (s *UnifiedSearchService) withQuerySlot(ctx context.Context, fn func() error) error {
+	select {
+	case s.queries <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-s.queries }()
+	return fn()
+}
+
+// buildTopHits pairs each section row with its ranking position and scores it.
+// Results and keys are parallel; set fills in the hit's type and item.
+func buildTopHits[T any](results []T, keys []database.SearchKeyset, set func(*dto.TopHit, *T)) []dto.TopHit {
+	hits := make([]dto.TopHit, 0, len(results))
+	for i := range results {
+		if i >= len(keys) {
+			break
+		}
+		hit := dto.TopHit{Score: normalizedSearchScore(keys[i])}
+		set(&hit, &results[i])
+		hits = append(hits, hit)
+	}
+	return hits
+}
+
+// normalizedSearchScore maps a row's ranking position onto [0, 1] so rows from
+// different entities can be compared. It keeps each entity's own order:
+// exact matches land in [0.9, 1], prefix matches in [0.6, 0.9], and
+// similarity-only matches in [0, 0.6].
+func normalizedSearchScore(k database.SearchKeyset) float64 {
+	sim := float64(k.Similarity)
+	switch {
+	case k.Exact > 0:
+		return 0.9 + 0.1*sim
+	case k.Prefix > 0:
+		return 0.6 + 0.3*sim
+	default:
+		return 0.6 * sim
+	}
+}
+
+// parseSearchTypes parses a comma-separated list of section names.
+// An empty list selects every section.
+func parseSearchTypes(types string) (map[string]bool, error) {
+	selected := make(map[string]bool, len(unifiedSearchSections))
+	if strings.TrimSpace(types) == "" {
+		for _, section := range unifiedSearchSections {
+			selected[section] = true
+		}
+		return selected, nil
+	}
+
+	for _, raw := range strings.Split(types, ",") {
+		section := strings.ToLower(strings.TrimSpace(raw))
+		switch section {
+		case dto.SearchTypeArtists, dto.SearchTypeSongs, dto.SearchTypeConcerts, dto.SearchTypeUsers:
+			selected[section] = true
+		default:
+			return nil, fmt.Errorf("%w: %q", apperr.ErrInvalidSearchType, raw)
+		}
+	}
+	return selected, nil
+}
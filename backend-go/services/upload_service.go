@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
@@ -66,12 +67,14 @@ func (s *UploadService) CDNURL(key string) string {
 	return fmt.Sprintf("%s/%s", s.cdnURL, key)
 }
 
-// CreateMultipartUpload initiates a multipart upload and returns the upload ID
+// CreateMultipartUpload initiates a multipart upload and returns the upload ID.
+// The object starts private; PlaybackService.SyncAccess opens it up once it is public.
 func (s *UploadService) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
 	output, err := s.s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 		Bucket:      aws.String(s.bucket),
 		Key:         aws.String(key),
 		ContentType: aws.String(contentType),
+		ACL:         s3Types.ObjectCannedACLPrivate,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create multipart upload: %w", err)
@@ -149,15 +152,71 @@ func (s *UploadService) PresignGet(ctx context.Context, key string, ttl time.Dur
 }
 
 // PutObject uploads data to the given S3 key and returns the CDN URL.
-func (s *UploadService) PutObject(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+// The CDN URL only works for public objects; private ones are read through PresignGet.
+func (s *UploadService) PutObject(ctx context.Context, key string, data []byte, contentType string, public bool) (string, error) {
 	_, err := s.s3Client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(s.bucket),
 		Key:         aws.String(key),
 		Body:        bytes.NewReader(data),
 		ContentType: aws.String(contentType),
+		ACL:         objectACL(public),
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to put object %s: %w", key, err)
 	}
 	return s.CDNURL(key), nil
 }
+
+// PutFile uploads the file at path to the given S3 key and returns the CDN URL.
+// Single-request upload, so files are limited to 5GB.
+func (s *UploadService) PutFile(ctx context.Context, key string, path string, contentType string, public bool) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	_, err = s.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        f,
+		ContentType: aws.String(contentType),
+		ACL:         objectACL(public),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	return s.CDNURL(key), nil
+}
+
+// DeleteObject removes the object at key. Deleting a key that doesn't exist succeeds.
+func (s *UploadService) DeleteObject(ctx context.Context, key string) error {
+	_, err := s.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %w", key, err)
+	}
+	return nil
+}
+
+// SetObjectPublic switches an existing object between public-read and private.
+func (s *UploadService) SetObjectPublic(ctx context.Context, key string, public bool) error {
+	_, err := s.s3Client.PutObjectAcl(ctx, &s3.PutObjectAclInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		ACL:    objectACL(public),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set ACL on %s: %w", key, err)
+	}
+	return nil
+}
+
+func objectACL(public bool) s3Types.ObjectCannedACL {
+	if public {
+		return s3Types.ObjectCannedACLPublicRead
+	}
+	return s3Types.ObjectCannedACLPrivate
+}
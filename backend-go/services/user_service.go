@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/areeeeeeeb/reLive/backend-go/database"
 	"github.com/areeeeeeeb/reLive/backend-go/dto"
@@ -9,29 +10,15 @@ import (
 )
 
 type UserService struct {
-	store         *database.Store
-	searchService *SearchService
+	store           *database.Store
+	searchService   *SearchService
+	reactionService *ReactionService
+	playbackService *PlaybackService
+	locationPrivacy *LocationPrivacyService
 }
 
-func NewUserService(store *database.Store, searchService *SearchService) *UserService {
-	return &UserService{store: store, searchService: searchService}
-}
-
-// Sync handles Auth0 login/signup — updates on conflict because user info
-// (email, display name) can change in the OAuth provider.
-func (s *UserService) Sync(ctx context.Context, auth0ID, email, username, displayName string) (*models.User, error) {
-	if displayName == "" {
-		displayName = username
-	}
-	user := &models.User{
-		Auth0ID:           auth0ID,
-		Email:             email,
-		Username:          username,
-		DisplayName:       displayName,
-		ProfilePictureURL: nil,
-		Bio:               nil,
-	}
-	return s.store.UpsertUser(ctx, user)
+func NewUserService(store *database.Store, searchService *SearchService, reactionService *ReactionService, playbackService *PlaybackService, locationPrivacy *LocationPrivacyService) *UserService {
+	return &UserService{store: store, searchService: searchService, reactionService: reactionService, playbackService: playbackService, locationPrivacy: locationPrivacy}
 }
 
 // GetByID retrieves a user by their internal ID.
@@ -39,29 +26,165 @@ func (s *UserService) GetByID(ctx context.Context, userID int) (*models.User, er
 	return s.store.GetUserByID(ctx, userID)
 }
 
+// GetProfile returns the public profile for username as seen by viewerID (0 when anonymous).
+// The owner's counts include their private videos and attendance.
+func (s *UserService) GetProfile(ctx context.Context, username string, viewerID int) (*dto.UserProfile, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.store.GetUserProfileCounts(ctx, user.ID, user.ID == viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &dto.UserProfile{
+		ID:                user.ID,
+		Username:          user.Username,
+		DisplayName:       user.DisplayName,
+		ProfilePictureURL: user.ProfilePictureURL,
+		Bio:               user.Bio,
+		JoinedAt:          user.CreatedAt,
+		Counts:            *counts,
+	}
+	if viewerID != 0 && viewerID != user.ID {
+		followed, err := s.store.ListFollowedUserIDs(ctx, viewerID, []int{user.ID})
+		if err != nil {
+			return nil, err
+		}
+		followedByMe := len(followed) > 0
+		profile.FollowedByMe = &followedByMe
+	}
+	return profile, nil
+}
+
+// ListVideosByUsername pages through a user's videos as seen by viewerID (0 when anonymous).
+func (s *UserService) ListVideosByUsername(ctx context.Context, username string, viewerID int, req dto.PageRequest) (*dto.UserVideosResponse, error) {
+	user, err := s.store.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return s.ListVideos(ctx, user.ID, viewerID, req)
+}
+
+// ListVideos pages through a user's videos as seen by viewerID (0 when anonymous), newest first.
+// The owner also sees their private videos and uploads in progress.
+func (s *UserService) ListVideos(ctx context.Context, userID int, viewerID int, req dto.PageRequest) (*dto.UserVideosResponse, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+
+	scope := PageScope{Name: pageScopeUserVideos, EntityID: userID}
+	var afterCreatedAt *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after videoCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterCreatedAt, afterID = &after.CreatedAt, after.ID
+	}
+
+	videos, err := s.store.ListVideosByUser(ctx, userID, userID == viewerID, afterCreatedAt, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	videos, hasMore := trimPage(videos, req.Limit)
+	if videos == nil {
+		videos = []*models.Video{}
+	}
+	if err := s.reactionService.Attach(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+	if err := s.playbackService.Sign(ctx, videos); err != nil {
+		return nil, err
+	}
+	if err := s.locationPrivacy.Apply(ctx, viewerID, videos); err != nil {
+		return nil, err
+	}
+
+	var last *videoCursor
+	if len(videos) > 0 {
+		v := videos[len(videos)-1]
+		last = &videoCursor{CreatedAt: v.CreatedAt, ID: v.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UserVideosResponse{Results: videos, Meta: meta}, nil
+}
+
 // UpdateProfile updates a user's display name, profile picture, and bio.
 // Null values for profilePicture and bio explicitly clear those fields.
 func (s *UserService) UpdateProfile(ctx context.Context, userID int, displayName string, profilePicture *string, bio *string) (*models.User, error) {
 	return s.store.UpdateUserProfile(ctx, userID, displayName, profilePicture, bio)
 }
 
+// SetUsername changes the user's username. From then on /users/sync leaves it alone.
+// Returns ErrDuplicate when another account holds it, ignoring case.
+func (s *UserService) SetUsername(ctx context.Context, userID int, username string) (*models.User, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	return s.store.SetUsername(ctx, userID, username)
+}
+
+// UsernameAvailable reports whether username is free. Invalid or reserved usernames
+// return the validation error instead.
+func (s *UserService) UsernameAvailable(ctx context.Context, username string) (bool, error) {
+	if err := ValidateUsername(username); err != nil {
+		return false, err
+	}
+	taken, err := s.store.UsernameTaken(ctx, username)
+	return !taken, err
+}
+
+// UpdatePrivacy changes a user's privacy settings; fields left nil in req are unchanged.
+func (s *UserService) UpdatePrivacy(ctx context.Context, userID int, req dto.UpdatePrivacyRequest) (*models.User, error) {
+	if req.LocationPrivacy == nil {
+		return s.store.GetUserByID(ctx, userID)
+	}
+	return s.store.SetUserLocationPrivacy(ctx, userID, *req.LocationPrivacy)
+}
+
 
 func (s *UserService) Search(ctx context.Context, req dto.SearchRequest) (*dto.UserSearchResponse, error) {
+	resp, _, err := s.searchRanked(ctx, req)
+	return resp, err
+}
+
+// searchRanked is Search, also returning each result's ranking position.
+func (s *UserService) searchRanked(ctx context.Context, req dto.SearchRequest) (*dto.UserSearchResponse, []database.SearchKeyset, error) {
 	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	users, err := s.store.SearchUsers(ctx, req.Q, req.MaxResults)
+	after, err := s.searchService.DecodeSearchCursor(searchScopeUsers, req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	results := s.BuildSearchResults(users)
-	meta := s.searchService.BuildSearchMeta(req, len(results))
+	page, err := s.store.SearchUsers(ctx, req.Q, after, req.MaxResults)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := s.BuildSearchResults(page.Items)
+	if req.ViewerID != 0 {
+		if err := s.markFollowed(ctx, req.ViewerID, results); err != nil {
+			return nil, nil, err
+		}
+	}
+	meta, err := s.searchService.BuildSearchMeta(searchScopeUsers, req, len(results), page.Next)
+	if err != nil {
+		return nil, nil, err
+	}
 	return &dto.UserSearchResponse{
 		Results: results,
 		Meta:    meta,
-	}, nil
+	}, page.Keys, nil
 }
 
 func (s *UserService) BuildSearchResults(users []models.User) []dto.UserSearchItem {
@@ -76,3 +199,33 @@ func (s *UserService) BuildSearchResults(users []models.User) []dto.UserSearchIt
 	}
 	return results
 }
+
+// markFollowed sets FollowedByMe on each result for an authenticated viewer.
+func (s *UserService) markFollowed(ctx context.Context, viewerID int, results []dto.UserSearchItem) error {
+	ids := make([]int, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	followedIDs, err := s.store.ListFollowedUserIDs(ctx, viewerID, ids)
+	if err != nil {
+		return err
+	}
+	followed := make(map[int]bool, len(followedIDs))
+	for _, id := range followedIDs {
+		followed[id] = true
+	}
+	for i := range results {
+		followedByMe := followed[results[i].ID]
+		results[i].FollowedByMe = &followedByMe
+	}
+	return nil
+}
+
+func userCompact(u models.User) *dto.UserCompact {
+	return &dto.UserCompact{
+		ID:                u.ID,
+		Username:          u.Username,
+		DisplayName:       u.DisplayName,
+		ProfilePictureURL: u.ProfilePictureURL,
+	}
+}
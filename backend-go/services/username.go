@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"strings"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+)
+
+const (
+	usernameMinLength = 3
+	usernameMaxLength = 30
+	usernameAttempts  = 5 // random-suffix retries before giving up on a provider username
+)
+
+// usernamePattern allows letters, digits, dots and underscores, starting and ending
+// with a letter or digit. Length is checked separately.
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9._]*[A-Za-z0-9])?$`)
+
+// usernameInvalidChars matches everything usernamePattern doesn't allow.
+var usernameInvalidChars = regexp.MustCompile(`[^A-Za-z0-9._]+`)
+
+// reservedUsernames would collide with routes or impersonate the service. Compared lowercase.
+var reservedUsernames = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"deleted":       true,
+	"help":          true,
+	"link":          true,
+	"me":            true,
+	"moderator":     true,
+	"relive":        true,
+	"root":          true,
+	"search":        true,
+	"settings":      true,
+	"support":       true,
+	"sync":          true,
+	"system":        true,
+}
+
+// ValidateUsername checks a username against the format rules and the reserved list.
+func ValidateUsername(username string) error {
+	if len(username) < usernameMinLength || len(username) > usernameMaxLength || !usernamePattern.MatchString(username) {
+		return apperr.ErrInvalidUsername
+	}
+	if reservedUsernames[strings.ToLower(username)] {
+		return apperr.ErrReservedUsername
+	}
+	return nil
+}
+
+// normalizeUsername turns whatever an identity provider reports (often an email
+// local part or a full name) into something ValidateUsername accepts, or "".
+func normalizeUsername(raw string) string {
+	if at := strings.IndexByte(raw, '@'); at >= 0 {
+		raw = raw[:at]
+	}
+	name := usernameInvalidChars.ReplaceAllString(raw, "_")
+	name = strings.Trim(name, "._")
+	if len(name) > usernameMaxLength {
+		name = strings.TrimRight(name[:usernameMaxLength], "._")
+	}
+	if ValidateUsername(name) != nil {
+		return ""
+	}
+	return name
+}
+
+// availableUsername returns base, or base with a random numeric suffix, that no
+// account holds yet. Falls back to "user" when base is empty.
+func availableUsername(ctx context.Context, store *database.Store, base string) (string, error) {
+	if base == "" {
+		base = "user"
+	}
+	candidate := base
+	for range usernameAttempts {
+		taken, err := store.UsernameTaken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		suffix := fmt.Sprintf("%d", 1000+rand.IntN(9000))
+		if len(base)+len(suffix) > usernameMaxLength {
+			base = strings.TrimRight(base[:usernameMaxLength-len(suffix)], "._")
+		}
+		candidate = base + suffix
+	}
+	return "", apperr.ErrDuplicate
+}
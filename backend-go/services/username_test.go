@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     error
+	}{
+		{"simple", "dkang", nil},
+		{"dots underscores digits", "d.kang_99", nil},
+		{"minimum length", "abc", nil},
+		{"maximum length", strings.Repeat("a", usernameMaxLength), nil},
+		{"empty", "", apperr.ErrInvalidUsername},
+		{"too short", "ab", apperr.ErrInvalidUsername},
+		{"too long", strings.Repeat("a", usernameMaxLength+1), apperr.ErrInvalidUsername},
+		{"leading underscore", "_dkang", apperr.ErrInvalidUsername},
+		{"trailing dot", "dkang.", apperr.ErrInvalidUsername},
+		{"space", "d kang", apperr.ErrInvalidUsername},
+		{"hyphen", "d-kang", apperr.ErrInvalidUsername},
+		{"non-ascii", "dkäng", apperr.ErrInvalidUsername},
+		{"reserved", "admin", apperr.ErrReservedUsername},
+		{"reserved ignores case", "ReLive", apperr.ErrReservedUsername},
+		{"reserved route name", "settings", apperr.ErrReservedUsername},
+		{"reserved prefix is fine", "admins", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUsername(tt.username)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ValidateUsername(%q) = %v, want %v", tt.username, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"already valid", "dkang", "dkang"},
+		{"email local part", "dkang@example.com", "dkang"},
+		{"full name", "Dave Kang", "Dave_Kang"},
+		{"invalid runs collapse", "dave -- kang", "dave_kang"},
+		{"non-ascii", "héllo wörld", "h_llo_w_rld"},
+		{"trims dots and underscores", "..dave__", "dave"},
+		{"trims after replacing", " dave! ", "dave"},
+		{"truncated to max length", strings.Repeat("a", 40), strings.Repeat("a", usernameMaxLength)},
+		{"trims separator left by truncation", strings.Repeat("a", usernameMaxLength-1) + "._bbbb", strings.Repeat("a", usernameMaxLength-1)},
+		{"empty", "", ""},
+		{"too short after trimming", "_a_", ""},
+		{"only invalid characters", "!!!", ""},
+		{"reserved", "admin@example.com", ""},
+		{"reserved ignores case", "Support", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeUsername(tt.raw); got != tt.want {
+				t.Errorf("normalizeUsername(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/apperr"
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/dto"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+type VenueService struct {
+	store          *database.Store
+	searchService  *SearchService
+	concertService *ConcertService
+}
+
+func NewVenueService(store *database.Store, searchService *SearchService, concertService *ConcertService) *VenueService {
+	return &VenueService{store: store, searchService: searchService, concertService: concertService}
+}
+
+// venueConcertCursor is the keyset position of the last concert on a venue history page.
+type venueConcertCursor struct {
+	Date time.Time `json:"d"`
+	ID   int       `json:"id"`
+}
+
+func (s *VenueService) Get(ctx context.Context, id int) (*models.Venue, error) {
+	return s.store.GetVenueByID(ctx, id)
+}
+
+func (s *VenueService) Search(ctx context.Context, req dto.SearchRequest) (*dto.VenueSearchResponse, error) {
+	if err := s.searchService.ValidateMaxResults(req.MaxResults); err != nil {
+		return nil, err
+	}
+
+	after, err := s.searchService.DecodeSearchCursor(searchScopeVenues, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.store.SearchVenues(ctx, req.Q, after, req.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	results := s.BuildSearchResults(page.Items)
+	meta, err := s.searchService.BuildSearchMeta(searchScopeVenues, req, len(results), page.Next)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.VenueSearchResponse{
+		Results: results,
+		Meta:    meta,
+	}, nil
+}
+
+func (s *VenueService) BuildSearchResults(venues []models.Venue) []dto.VenueSearchItem {
+	results := make([]dto.VenueSearchItem, 0, len(venues))
+	for _, venue := range venues {
+		results = append(results, dto.VenueSearchItem{
+			ID:          venue.ID,
+			Name:        venue.Name,
+			City:        venue.City,
+			Region:      venue.Region,
+			CountryCode: venue.CountryCode,
+		})
+	}
+	return results
+}
+
+// Nearby returns venues within radiusMeters of the given point, closest first.
+func (s *VenueService) Nearby(ctx context.Context, lat, lng, radiusMeters float64, maxResults int) ([]models.NearbyVenue, error) {
+	if radiusMeters <= 0 || radiusMeters > dto.NearbyRadiusMetersMax {
+		return nil, fmt.Errorf("invalid radius_m: %.0f (must be 1-%d)", radiusMeters, dto.NearbyRadiusMetersMax)
+	}
+	if err := s.searchService.ValidateMaxResults(maxResults); err != nil {
+		return nil, err
+	}
+	return s.store.ListVenuesNearby(ctx, lat, lng, radiusMeters, maxResults)
+}
+
+// ListConcerts pages through the venue's concert history, most recent first.
+func (s *VenueService) ListConcerts(ctx context.Context, venueID int, req dto.PageRequest) (*dto.ConcertPage, error) {
+	if err := validatePageLimit(req.Limit); err != nil {
+		return nil, err
+	}
+	exists, err := s.store.VenueExists(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apperr.ErrNotFound
+	}
+
+	scope := PageScope{Name: pageScopeVenueConcerts, EntityID: venueID}
+	var afterDate *time.Time
+	var afterID int
+	if req.Cursor != "" {
+		var after venueConcertCursor
+		if err := s.searchService.DecodePageCursor(scope, req.Cursor, &after); err != nil {
+			return nil, err
+		}
+		afterDate, afterID = &after.Date, after.ID
+	}
+
+	concerts, err := s.store.ListConcertsByVenue(ctx, venueID, afterDate, afterID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	concerts, hasMore := trimPage(concerts, req.Limit)
+
+	results, err := s.concertService.BuildCards(ctx, concerts)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *venueConcertCursor
+	if len(concerts) > 0 {
+		c := concerts[len(concerts)-1]
+		last = &venueConcertCursor{Date: c.Date, ID: c.ID}
+	}
+	meta, err := s.searchService.BuildPageMeta(scope, hasMore, last)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConcertPage{Results: results, Meta: meta}, nil
+}
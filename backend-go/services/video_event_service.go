@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+	"github.com/areeeeeeeb/reLive/backend-go/models"
+)
+
+const (
+	// videoEventBuffer is how many events a slow stream can fall behind before new ones are dropped for it.
+	videoEventBuffer = 16
+	// videoEventRetryDelay is how long to wait before re-LISTENing after the listener connection fails.
+	videoEventRetryDelay = 5 * time.Second
+)
+
+// VideoEventService fans video status transitions out to their owners' open event streams.
+// Transitions arrive through Postgres LISTEN/NOTIFY, so one written by any API replica
+// reaches the streams held by every replica.
+type VideoEventService struct {
+	store *database.Store
+
+	mu          sync.Mutex
+	subscribers map[int]map[chan models.VideoEvent]struct{} // user ID -> open streams
+}
+
+func NewVideoEventService(store *database.Store) *VideoEventService {
+	return &VideoEventService{
+		store:       store,
+		subscribers: make(map[int]map[chan models.VideoEvent]struct{}),
+	}
+}
+
+// Start launches the listener in a background goroutine.
+func (s *VideoEventService) Start(ctx context.Context) {
+	go s.runListenLoop(ctx)
+	log.Println("[video-events] started")
+}
+
+// runListenLoop keeps a LISTEN connection open until ctx is done, reconnecting after failures.
+// Events published while reconnecting are lost; clients refetch the video after reconnecting.
+func (s *VideoEventService) runListenLoop(ctx context.Context) {
+	for {
+		err := s.store.ListenVideoEvents(ctx, s.publish)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[video-events] listener stopped: %v; retrying in %s", err, videoEventRetryDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(videoEventRetryDelay):
+		}
+	}
+}
+
+// Subscribe opens a stream of userID's video events.
+// The caller must call the returned func once it stops reading.
+func (s *VideoEventService) Subscribe(userID int) (<-chan models.VideoEvent, func()) {
+	ch := make(chan models.VideoEvent, videoEventBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan models.VideoEvent]struct{})
+	}
+	s.subscribers[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[userID], ch)
+		if len(s.subscribers[userID]) == 0 {
+			delete(s.subscribers, userID)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publish hands e to each of its owner's streams without blocking the listener.
+func (s *VideoEventService) publish(e models.VideoEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[e.UserID] {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("[video-events] user %d: stream is full, dropping event for video %d", e.UserID, e.VideoID)
+		}
+	}
+}
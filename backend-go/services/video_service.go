@@ -2,7 +2,12 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"fmt"
+	"log"
+	"strconv"
 	"strings"
 
 	"github.com/areeeeeeeb/reLive/backend-go/apperr"
@@ -17,8 +22,13 @@ const (
 )
 
 type VideoService struct {
-	store         *database.Store
-	uploadService *UploadService
+	store             *database.Store
+	uploadService     *UploadService
+	attendanceService *AttendanceService
+	reactionService   *ReactionService
+	playbackService   *PlaybackService
+	locationPrivacy   *LocationPrivacyService
+	viewCounter       *ViewCounter
 }
 
 // InitUploadResult is the domain result of initiating an upload
@@ -29,10 +39,15 @@ type InitUploadResult struct {
 	PartSize int64
 }
 
-func NewVideoService(store *database.Store, upload *UploadService) *VideoService {
+func NewVideoService(store *database.Store, upload *UploadService, attendance *AttendanceService, reactions *ReactionService, playback *PlaybackService, locationPrivacy *LocationPrivacyService, views *ViewCounter) *VideoService {
 	return &VideoService{
-		store:         store,
-		uploadService: upload,
+		store:             store,
+		uploadService:     upload,
+		attendanceService: attendance,
+		reactionService:   reactions,
+		playbackService:   playback,
+		locationPrivacy:   locationPrivacy,
+		viewCounter:       views,
 	}
 }
 
@@ -103,6 +118,17 @@ func (s *VideoService) ConfirmUpload(ctx context.Context, videoID int, userID in
 		return fmt.Errorf("failed to set upload status completed: %w", err)
 	}
 
+	// uploads start private; open public videos up to the CDN
+	if video.Visibility == models.VideoVisibilityPublic {
+		if err := s.playbackService.SyncAccess(ctx, video); err != nil {
+			return fmt.Errorf("failed to make video public: %w", err)
+		}
+	}
+
+	if video.EventType != nil && *video.EventType == models.EventTypeConcert && video.EventID != nil {
+		s.recordAttendance(ctx, userID, *video.EventID, models.AttendanceSourceVideo)
+	}
+
 	return nil
 }
 
@@ -111,9 +137,252 @@ func (s *VideoService) GetByID(ctx context.Context, videoID int) (*models.Video,
 	return s.store.GetVideoByID(ctx, videoID)
 }
 
-// SetConcert links a video to a concert
+// Get returns a video viewerID (0 when anonymous) may see, with its engagement counts.
+// shareToken opens an unlisted video ("" when the viewer has none).
+// Videos the viewer may not see are reported as not found.
+func (s *VideoService) Get(ctx context.Context, videoID int, viewerID int, shareToken string) (*models.Video, error) {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if !videoVisibleTo(video, viewerID, shareToken) {
+		return nil, apperr.ErrNotFound
+	}
+	if video.UserID != viewerID {
+		video.ShareToken = nil
+	}
+	if err := s.reactionService.Attach(ctx, viewerID, []*models.Video{video}); err != nil {
+		return nil, err
+	}
+	if err := s.playbackService.Sign(ctx, []*models.Video{video}); err != nil {
+		return nil, err
+	}
+	if err := s.locationPrivacy.Apply(ctx, viewerID, []*models.Video{video}); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// RecordView counts a view of a video the viewer may see. Views are de-duplicated
+// per user when authenticated (viewerID != 0), otherwise per client IP.
+func (s *VideoService) RecordView(ctx context.Context, videoID int, viewerID int, shareToken string, clientIP string) error {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if !videoVisibleTo(video, viewerID, shareToken) {
+		return apperr.ErrNotFound
+	}
+	viewer := "ip:" + clientIP
+	if viewerID != 0 {
+		viewer = "user:" + strconv.Itoa(viewerID)
+	}
+	s.viewCounter.Record(videoID, viewer)
+	return nil
+}
+
+// videoVisibleTo reports whether viewerID (0 when anonymous) may see video:
+// owners see their own videos; everyone else sees uploaded videos that are public,
+// or unlisted when shareToken matches the video's current token.
+// Listings never include unlisted videos, so they only check for public ones.
+func videoVisibleTo(video *models.Video, viewerID int, shareToken string) bool {
+	if viewerID != 0 && video.UserID == viewerID {
+		return true
+	}
+	if video.Status != models.VideoStatusCompleted {
+		return false
+	}
+	switch video.Visibility {
+	case models.VideoVisibilityPublic:
+		return true
+	case models.VideoVisibilityUnlisted:
+		return shareToken != "" && video.ShareToken != nil &&
+			subtle.ConstantTimeCompare([]byte(shareToken), []byte(*video.ShareToken)) == 1
+	}
+	return false
+}
+
+// Update changes the owner's video settings; fields left nil in req are unchanged.
+// Making a video unlisted issues a share token; leaving unlisted revokes it.
+// Setting the visibility also syncs the stored objects' access (see PlaybackService).
+// Linking an uploaded video to a concert records the owner as attending it.
+func (s *VideoService) Update(ctx context.Context, videoID int, userID int, req dto.UpdateVideoRequest) (*models.Video, error) {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	if video.UserID != userID {
+		return nil, apperr.ErrForbidden
+	}
+
+	visibility := video.Visibility
+	if req.Visibility != nil {
+		visibility = *req.Visibility
+	}
+
+	caption := video.Caption
+	if req.Caption != nil {
+		caption = nil
+		if trimmed := strings.TrimSpace(*req.Caption); trimmed != "" {
+			caption = &trimmed
+		}
+	}
+
+	eventType, eventID := video.EventType, video.EventID
+	linkedConcertID := 0
+	if req.ConcertID != nil {
+		switch {
+		case *req.ConcertID == 0:
+			eventType, eventID = nil, nil
+		case eventID == nil || *eventID != *req.ConcertID:
+			exists, err := s.store.ConcertExists(ctx, *req.ConcertID)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, apperr.ErrNotFound
+			}
+			concertType := models.EventTypeConcert
+			eventType, eventID = &concertType, req.ConcertID
+			linkedConcertID = *req.ConcertID
+		}
+	}
+
+	shareToken := video.ShareToken
+	if visibility != models.VideoVisibilityUnlisted {
+		shareToken = nil
+	} else if shareToken == nil {
+		token, err := newShareToken()
+		if err != nil {
+			return nil, err
+		}
+		shareToken = &token
+	}
+
+	// flip object access before the row so a failure never leaves a private video CDN-readable;
+	// retrying the request re-syncs it. Pending uploads have no object yet: ConfirmUpload
+	// applies the stored visibility once the upload completes.
+	if req.Visibility != nil && video.Status == models.VideoStatusCompleted {
+		target := *video
+		target.Visibility = visibility
+		if err := s.playbackService.SyncAccess(ctx, &target); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.store.UpdateVideoSettings(ctx, videoID, visibility, caption, eventType, eventID, shareToken)
+	if err != nil {
+		return nil, err
+	}
+	if linkedConcertID != 0 && updated.Status == models.VideoStatusCompleted {
+		s.recordAttendance(ctx, userID, linkedConcertID, models.AttendanceSourceVideo)
+	}
+	if err := s.reactionService.Attach(ctx, userID, []*models.Video{updated}); err != nil {
+		return nil, err
+	}
+	if err := s.playbackService.Sign(ctx, []*models.Video{updated}); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// RotateShareToken replaces the share token of the owner's unlisted video and returns
+// the new one. Links made from the old token stop working.
+func (s *VideoService) RotateShareToken(ctx context.Context, videoID int, userID int) (string, error) {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return "", err
+	}
+	if video.UserID != userID {
+		return "", apperr.ErrForbidden
+	}
+	if video.Visibility != models.VideoVisibilityUnlisted {
+		return "", apperr.ErrNotUnlisted
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.store.SetVideoShareToken(ctx, videoID, &token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeShareToken stops every share link of the owner's video from working.
+// The video stays unlisted; RotateShareToken issues a fresh link.
+func (s *VideoService) RevokeShareToken(ctx context.Context, videoID int, userID int) error {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if video.UserID != userID {
+		return apperr.ErrForbidden
+	}
+	return s.store.SetVideoShareToken(ctx, videoID, nil)
+}
+
+// newShareToken returns a random, URL-safe share token.
+func newShareToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// SetConcert links a video to a concert. Once the upload has completed, the
+// uploader is recorded as attending the concert.
 func (s *VideoService) SetConcert(ctx context.Context, videoID int, concertID int) error {
-	return s.store.SetVideoConcert(ctx, videoID, concertID)
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if err := s.store.SetVideoConcert(ctx, videoID, concertID); err != nil {
+		return err
+	}
+	if video.Status == models.VideoStatusCompleted {
+		s.recordAttendance(ctx, video.UserID, concertID, models.AttendanceSourceVideo)
+	}
+	return nil
+}
+
+// ConfirmConcert accepts a detection candidate: it links the user's video to the
+// concert, marks the video as detected and records the user as attending.
+func (s *VideoService) ConfirmConcert(ctx context.Context, videoID int, userID int, concertID int) error {
+	video, err := s.store.GetVideoByID(ctx, videoID)
+	if err != nil {
+		return err
+	}
+	if video.UserID != userID {
+		return apperr.ErrForbidden
+	}
+
+	exists, err := s.store.ConcertExists(ctx, concertID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apperr.ErrNotFound
+	}
+
+	if err := s.store.SetVideoConcert(ctx, videoID, concertID); err != nil {
+		return err
+	}
+	if err := s.store.SetDetectionStatus(ctx, videoID, models.VideoDetectionStatusDetected); err != nil {
+		return err
+	}
+	s.recordAttendance(ctx, userID, concertID, models.AttendanceSourceDetection)
+	return nil
+}
+
+// recordAttendance is best-effort: a missed attendance record must not fail the
+// upload or link that triggered it, and the user can still mark it by hand.
+func (s *VideoService) recordAttendance(ctx context.Context, userID int, concertID int, source string) {
+	if err := s.attendanceService.Record(ctx, userID, concertID, source); err != nil {
+		log.Printf("[attendance] failed to record user %d at concert %d (%s): %v", userID, concertID, source, err)
+	}
 }
 
 // SetSong links a video to a song
@@ -121,12 +390,21 @@ func (s *VideoService) SetSong(ctx context.Context, videoID int, songID int) err
 	return s.store.SetVideoSong(ctx, videoID, songID)
 }
 
-func (s *VideoService) ListByConcert(ctx context.Context, concertID int) ([]*models.Video, error) {
-	videos, err := s.store.ListVideosByConcert(ctx, concertID)
+func (s *VideoService) ListByConcert(ctx context.Context, concertID int, viewerID int) ([]*models.Video, error) {
+	videos, err := s.store.ListVideosByConcert(ctx, concertID, viewerID)
 	if err != nil {
 		return nil, err
 	}
 	if len(videos) > 0 {
+		if err := s.reactionService.Attach(ctx, viewerID, videos); err != nil {
+			return nil, err
+		}
+		if err := s.playbackService.Sign(ctx, videos); err != nil {
+			return nil, err
+		}
+		if err := s.locationPrivacy.Apply(ctx, viewerID, videos); err != nil {
+			return nil, err
+		}
 		return videos, nil
 	}
 
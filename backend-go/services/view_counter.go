@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/areeeeeeeb/reLive/backend-go/database"
+)
+
+// viewFlushTimeout bounds the final flush on shutdown, when the run context is already done.
+const viewFlushTimeout = 5 * time.Second
+
+// ViewCounter counts video views without a DB write per view.
+// A viewer counts at most once per video per dedupeWindow; counted views are
+// buffered in memory and added to videos.view_count every flushInterval.
+//
+// De-duplication is per process, so with several replicas a viewer bouncing between
+// them can be counted once per replica per window. Views buffered when a process
+// dies without a clean shutdown are lost — view counts tolerate both.
+type ViewCounter struct {
+	store         *database.Store
+	dedupeWindow  time.Duration
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	seen    map[viewKey]time.Time // last counted view per viewer per video
+	pending map[int]int64         // video ID -> views not yet flushed
+
+	done chan struct{} // closed once the flush loop has made its final flush
+}
+
+type viewKey struct {
+	videoID int
+	viewer  string
+}
+
+func NewViewCounter(store *database.Store, dedupeWindow time.Duration, flushInterval time.Duration) *ViewCounter {
+	return &ViewCounter{
+		store:         store,
+		dedupeWindow:  dedupeWindow,
+		flushInterval: flushInterval,
+		seen:          make(map[viewKey]time.Time),
+		pending:       make(map[int]int64),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start launches the flush loop in a background goroutine. Cancelling ctx flushes
+// once more and stops the loop; Wait blocks until that final flush is done.
+func (c *ViewCounter) Start(ctx context.Context) {
+	go c.runFlushLoop(ctx)
+	log.Println("[views] started")
+}
+
+// Wait blocks until the flush loop started by Start has stopped.
+func (c *ViewCounter) Wait() {
+	<-c.done
+}
+
+// Record counts a view of videoID by viewer (a user or client key) unless the same
+// viewer was already counted within the dedupe window. It reports whether the view counted.
+func (c *ViewCounter) Record(videoID int, viewer string) bool {
+	now := time.Now()
+	key := viewKey{videoID: videoID, viewer: viewer}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if last, ok := c.seen[key]; ok && now.Sub(last) < c.dedupeWindow {
+		return false
+	}
+	c.seen[key] = now
+	c.pending[videoID]++
+	return true
+}
+
+// runFlushLoop flushes every flushInterval, and once more on shutdown.
+func (c *ViewCounter) runFlushLoop(ctx context.Context) {
+	defer close(c.done)
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), viewFlushTimeout)
+			c.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+// flush writes buffered views in one batch and forgets viewers outside the dedupe window.
+// A failed batch is put back and retried on the next flush.
+func (c *ViewCounter) flush(ctx context.Context) {
+	c.mu.Lock()
+	batch := c.pending
+	c.pending = make(map[int]int64)
+	cutoff := time.Now().Add(-c.dedupeWindow)
+	for key, last := range c.seen {
+		if last.Before(cutoff) {
+			delete(c.seen, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if len(batch) == 0 {
+		return
+	}
+	if err := c.store.AddVideoViews(ctx, batch); err != nil {
+		log.Printf("[views] failed to flush views for %d videos: %v", len(batch), err)
+		c.mu.Lock()
+		for id, n := range batch {
+			c.pending[id] += n
+		}
+		c.mu.Unlock()
+	}
+}